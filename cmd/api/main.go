@@ -41,6 +41,8 @@ func main() {
 	}
 	defer pool.Close()
 	cases := usecase.Setup(cfg, pool)
+	go cases.Rebalancer.Start(ctx)
+	go cases.Absences.Start(ctx)
 
 	s := gateway.NewServer(ctx, cfg, cases)
 	if err := s.Run(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
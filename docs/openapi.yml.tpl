@@ -11,6 +11,10 @@ tags:
   - name: Teams
   - name: Users
   - name: PullRequests
+  - name: Ownership
+  - name: Rules
+  - name: Rebalance
+  - name: Stats
   - name: Health
 
 components:
@@ -29,6 +33,13 @@ components:
       schema:
         type: string
       description: Идентификатор пользователя
+    PullRequestIdQuery:
+      name: pull_request_id
+      in: query
+      required: true
+      schema:
+        type: string
+      description: Идентификатор PR
   schemas:
     ErrorResponse:
       type: object
@@ -44,11 +55,30 @@ components:
                 - TEAM_EXISTS
                 - PR_EXISTS
                 - PR_MERGED
+                - PR_CLOSED
+                - PR_DRAFT
                 - NOT_ASSIGNED
                 - NO_CANDIDATE
                 - NOT_FOUND
+                - INVALID_OWNERSHIP
+                - INVALID_POLICY
+                - INVALID_RULE
+                - INVALID_SCHEDULE
+                - CAPACITY_EXHAUSTED
+                - REVIEWER_LIMIT
+                - INVALID_ABSENCE
+                - INVALID_DECLINE
+                - INVALID_VERDICT
+                - INVALID_OVERRIDE
+                - INVALID_UPDATE
+                - NOT_APPROVED
+                - REVIEW_SUBMITTED
+                - FORBIDDEN
             message:
               type: string
+            details:
+              type: object
+              description: Дополнительные данные об ошибке; для NOT_APPROVED — ApprovalStatus
       example:
         error:
           code: NOT_FOUND
@@ -63,16 +93,95 @@ components:
           type: string
         is_active:
           type: boolean
+        level:
+          $ref: '#/components/schemas/UserLevel'
+        timezone:
+          type: string
+          description: Часовой пояс IANA, например Europe/Moscow
+        work_start:
+          type: string
+          pattern: '^\d{2}:\d{2}$'
+          description: Начало рабочего дня (HH:MM, местное время)
+        work_end:
+          type: string
+          pattern: '^\d{2}:\d{2}$'
+          description: Конец рабочего дня (HH:MM, местное время); может быть меньше начала для ночных смен
+        max_open_reviews:
+          type: integer
+          minimum: 0
+          description: Лимит открытых ревью пользователя (0 — без лимита); если не задан, действует лимит команды
+        skills:
+          type: array
+          items:
+            type: string
+          description: Навыки участника (например go, postgres, frontend); если передан, заменяет текущий список
+    UserLevel:
+      type: string
+      enum: [JUNIOR, MIDDLE, SENIOR, LEAD]
+      description: Уровень пользователя (по умолчанию MIDDLE); SENIOR и LEAD считаются старшими
+    ReviewerStrategy:
+      type: string
+      enum: [RANDOM, ROUND_ROBIN, LEAST_LOADED, WEIGHTED]
+      description: Стратегия выбора ревьюверов команды (по умолчанию LEAST_LOADED)
     Team:
       type: object
       required: [ team_name, members]
       properties:
         team_name:
           type: string
+        reviewer_strategy:
+          $ref: '#/components/schemas/ReviewerStrategy'
+        fallback_teams:
+          type: array
+          items:
+            type: string
+          description: Упорядоченный список команд, из которых добираются ревьюверы, если в команде не хватает кандидатов
         members:
           type: array
           items:
             $ref: '#/components/schemas/TeamMember'
+    WorkingHoursMode:
+      type: string
+      enum: [OFF, SOFT, STRICT]
+      description: |
+        OFF — рабочие часы не учитываются;
+        SOFT — предпочитаются ревьюверы в рабочем времени (по умолчанию);
+        STRICT — ревьюверы вне рабочего времени не назначаются
+    TeamPolicy:
+      type: object
+      required: [ team_name, min_reviewers, max_reviewers, allow_cross_team, require_senior, working_hours_mode, start_within_hours, max_open_reviews, required_approvals ]
+      properties:
+        team_name:
+          type: string
+        min_reviewers:
+          type: integer
+          minimum: 0
+          description: Минимум ревьюверов; PR с меньшим числом (или без ревьюверов при min_reviewers=0) помечается under_reviewed и ждёт в очереди
+        max_reviewers:
+          type: integer
+          minimum: 0
+          description: Сколько ревьюверов назначается на PR
+        allow_cross_team:
+          type: boolean
+          description: Разрешено ли назначать ревьюверов из других команд (владельцы путей, резервные команды)
+        require_senior:
+          type: boolean
+          description: Среди назначенных ревьюверов должен быть хотя бы один SENIOR или LEAD; при переназначении гарантия сохраняется
+        working_hours_mode:
+          $ref: '#/components/schemas/WorkingHoursMode'
+        start_within_hours:
+          type: integer
+          minimum: 0
+          maximum: 24
+          description: Ревьювер считается доступным, если его рабочий день начинается не позже чем через столько часов
+        max_open_reviews:
+          type: integer
+          minimum: 0
+          description: Лимит открытых ревью по умолчанию для участников команды (0 — без лимита)
+        required_approvals:
+          type: integer
+          minimum: 0
+          description: Сколько назначенных ревьюверов должны одобрить PR перед слиянием (0 — без одобрений, не больше min_reviewers)
     User:
       type: object
       required: [ user_id, username, team_name, is_active ]
@@ -85,6 +194,23 @@ components:
           type: string
         is_active:
           type: boolean
+        level:
+          $ref: '#/components/schemas/UserLevel'
+        timezone:
+          type: string
+          description: Часовой пояс IANA, например Europe/Moscow
+        work_start:
+          type: string
+          pattern: '^\d{2}:\d{2}$'
+          description: Начало рабочего дня (HH:MM, местное время)
+        work_end:
+          type: string
+          pattern: '^\d{2}:\d{2}$'
+          description: Конец рабочего дня (HH:MM, местное время); может быть меньше начала для ночных смен
+        max_open_reviews:
+          type: integer
+          minimum: 0
+          description: Лимит открытых ревью пользователя (0 — без лимита); если не задан, действует лимит команды
     PullRequest:
       type: object
       required: [ pull_request_id, pull_request_name, author_id, status, assigned_reviewers]
@@ -93,16 +219,45 @@ components:
           type: string
         pull_request_name:
           type: string
+        description:
+          type: string
         author_id:
           type: string
         status:
           type: string
-          enum: [OPEN, MERGED]
+          enum: [DRAFT, OPEN, MERGED, CLOSED]
+        metadata:
+          type: object
+          additionalProperties:
+            type: string
+          description: Произвольные строковые метаданные PR
+        labels:
+          type: array
+          items:
+            type: string
         assigned_reviewers:
           type: array
           items:
             type: string
           description: user_id назначенных ревьюверов (0..2)
+        reviewers:
+          type: array
+          items:
+            $ref: '#/components/schemas/ReviewerState'
+          description: Назначенные ревьюверы с состоянием каждого
+        fallback_reviewers:
+          type: array
+          items:
+            type: string
+          description: Ревьюверы, назначенные из резервных команд (присутствует в ответах create/reassign)
+        under_reviewed:
+          type: boolean
+          description: true, если назначено меньше ревьюверов, чем требует политика команды (хотя бы один, если max_reviewers > 0), или не назначен требуемый старший ревьювер
+        rule_violations:
+          type: array
+          items:
+            $ref: '#/components/schemas/RuleViolation'
+          description: Правила, нарушенные ради достижения минимума ревьюверов (присутствует в ответах create/reassign)
         createdAt:
           type: string
           format: date-time
@@ -111,6 +266,432 @@ components:
           type: string
           format: date-time
           nullable: true
+        closedAt:
+          type: string
+          format: date-time
+          description: Присутствует у PR в состоянии CLOSED
+    AssignmentTrace:
+      type: object
+      required: [ id, pull_request_id, action, strategy, seed, candidates, excluded, selected, createdAt ]
+      properties:
+        id:
+          type: integer
+          format: int64
+        pull_request_id:
+          type: string
+        action:
+          type: string
+          enum: [CREATE, REASSIGN, TOP_UP, REASSIGN_CHOSEN, ADD_CHOSEN, READY]
+        strategy:
+          $ref: '#/components/schemas/ReviewerStrategy'
+        seed:
+          type: integer
+          format: int64
+          description: Seed генератора случайных чисел, позволяющий воспроизвести выбор
+        labels:
+          type: array
+          items:
+            type: string
+          description: Метки PR, с которыми сопоставлялись навыки кандидатов
+        candidates:
+          type: array
+          items:
+            type: string
+          description: user_id рассмотренных кандидатов
+        pools:
+          type: array
+          description: Пулы кандидатов в порядке приоритета и выбранные из них ревьюверы
+          items:
+            type: object
+            required: [ source, candidates, selected ]
+            properties:
+              source:
+                type: string
+                enum: [CODE_OWNERS, TEAM, FALLBACK]
+              team_name:
+                type: string
+              candidates:
+                type: array
+                items:
+                  type: string
+              selected:
+                type: array
+                items:
+                  type: string
+        excluded:
+          type: array
+          items:
+            type: object
+            required: [ user_id, reason ]
+            properties:
+              user_id:
+                type: string
+              reason:
+                type: string
+                enum: [AUTHOR, INACTIVE, ABSENT, ALREADY_ASSIGNED, RULE_VIOLATION, OFF_HOURS, AT_CAPACITY, REMOVED]
+        selected:
+          type: array
+          items:
+            type: string
+        senior_pick:
+          type: string
+          description: Старший ревьювер, выбранный первым по требованию политики require_senior
+        random_pick:
+          type: string
+          description: Ревьювер, выбранный случайно вне зависимости от навыков (при наличии меток и двух и более мест)
+        violations:
+          type: array
+          items:
+            $ref: '#/components/schemas/RuleViolation'
+        loads:
+          type: object
+          additionalProperties:
+            type: integer
+          description: Число открытых ревью каждого кандидата на момент назначения (учитывается стратегиями LEAST_LOADED и WEIGHTED)
+        cursors:
+          type: object
+          additionalProperties:
+            type: string
+          description: Курсор ROUND_ROBIN каждой команды до выбора
+        createdAt:
+          type: string
+          format: date-time
+    AssignmentPreview:
+      type: object
+      required: [ author_id, strategy, labels, proposed_reviewers, under_reviewed, candidates, rule_violations, warnings ]
+      properties:
+        author_id:
+          type: string
+        strategy:
+          $ref: '#/components/schemas/ReviewerStrategy'
+        labels:
+          type: array
+          items:
+            type: string
+        proposed_reviewers:
+          type: array
+          items:
+            type: string
+        fallback_reviewers:
+          type: array
+          items:
+            type: string
+        under_reviewed:
+          type: boolean
+        candidates:
+          type: array
+          description: Все рассмотренные пользователи; для неподходящих указана причина
+          items:
+            type: object
+            required: [ user_id, eligible, selected ]
+            properties:
+              user_id:
+                type: string
+              source:
+                type: string
+                enum: [CODE_OWNERS, TEAM, FALLBACK]
+              team_name:
+                type: string
+              eligible:
+                type: boolean
+              reason:
+                type: string
+                enum: [AUTHOR, INACTIVE, ABSENT, ALREADY_ASSIGNED, RULE_VIOLATION, OFF_HOURS, AT_CAPACITY, REMOVED]
+              selected:
+                type: boolean
+        rule_violations:
+          type: array
+          items:
+            $ref: '#/components/schemas/RuleViolation'
+        warnings:
+          type: array
+          items:
+            type: object
+            required: [ code, message ]
+            properties:
+              code:
+                type: string
+                enum: [UNDERSTAFFED, CAPACITY_EXHAUSTED, NO_SENIOR, RULE_VIOLATION, FALLBACK_REVIEWERS]
+              message:
+                type: string
+    CodeOwner:
+      type: object
+      required: [ type, id ]
+      properties:
+        type:
+          type: string
+          enum: [USER, TEAM]
+        id:
+          type: string
+          description: user_id или team_name владельца
+    OwnershipRule:
+      type: object
+      required: [ pattern, owners ]
+      properties:
+        pattern:
+          type: string
+          description: Glob-шаблон пути в формате CODEOWNERS (последнее совпавшее правило побеждает)
+        owners:
+          type: array
+          items:
+            $ref: '#/components/schemas/CodeOwner'
+    RuleType:
+      type: string
+      enum: [AVOID, PREFER, NO_REPEAT]
+      description: |
+        AVOID — не назначать reviewer_id на PR автора author_id;
+        PREFER — назначать reviewer_id в первую очередь; вместо пары пользователей можно задать reviewer_level
+        и, необязательно, author_level (например, JUNIOR → SENIOR);
+        NO_REPEAT — не назначать ревьюверов предыдущего PR автора (author_id не задан — для всех авторов)
+    ReviewerRule:
+      type: object
+      required: [ id, type, createdAt ]
+      properties:
+        id:
+          type: integer
+          format: int64
+        type:
+          $ref: '#/components/schemas/RuleType'
+        author_id:
+          type: string
+        reviewer_id:
+          type: string
+        author_level:
+          $ref: '#/components/schemas/UserLevel'
+        reviewer_level:
+          $ref: '#/components/schemas/UserLevel'
+        createdAt:
+          type: string
+          format: date-time
+    RuleViolation:
+      type: object
+      required: [ rule_id, type, reviewer_id ]
+      properties:
+        rule_id:
+          type: integer
+          format: int64
+        type:
+          $ref: '#/components/schemas/RuleType'
+        reviewer_id:
+          type: string
+    Reassignment:
+      type: object
+      required: [ pull_request_id, team_name, from_user_id, to_user_id, reason, createdAt ]
+      properties:
+        id:
+          type: integer
+          format: int64
+          description: Отсутствует у ходов из пробного прогона
+        pull_request_id:
+          type: string
+        team_name:
+          type: string
+        from_user_id:
+          type: string
+        to_user_id:
+          type: string
+        reason:
+          type: string
+          enum: [REBALANCE]
+        createdAt:
+          type: string
+          format: date-time
+    RebalanceReport:
+      type: object
+      required: [ dry_run, teams, moves, generatedAt ]
+      properties:
+        dry_run:
+          type: boolean
+        teams:
+          type: array
+          description: Разница между самым загруженным и самым свободным активным участником до и после перераспределения
+          items:
+            type: object
+            required: [ team_name, skew_before, skew_after ]
+            properties:
+              team_name:
+                type: string
+              skew_before:
+                type: integer
+              skew_after:
+                type: integer
+        moves:
+          type: array
+          items:
+            $ref: '#/components/schemas/Reassignment'
+        generatedAt:
+          type: string
+          format: date-time
+    Absence:
+      type: object
+      required: [ id, user_id, startsAt, endsAt, createdAt ]
+      properties:
+        id:
+          type: integer
+          format: int64
+        user_id:
+          type: string
+        startsAt:
+          type: string
+          format: date-time
+        endsAt:
+          type: string
+          format: date-time
+        reason:
+          type: string
+        handledAt:
+          type: string
+          format: date-time
+          description: Когда открытые ревью пользователя были переданы другим ревьюверам
+        createdAt:
+          type: string
+          format: date-time
+    DeclineReason:
+      type: string
+      enum: [TOO_BUSY, NO_CONTEXT, CONFLICT]
+      description: >
+        TOO_BUSY — нет времени;
+        NO_CONTEXT — не хватает контекста;
+        CONFLICT — конфликт интересов
+    ReviewVerdict:
+      type: string
+      enum: [APPROVED, CHANGES_REQUESTED, COMMENTED]
+    Review:
+      type: object
+      required: [ id, pull_request_id, user_id, verdict, createdAt ]
+      properties:
+        id:
+          type: integer
+          format: int64
+        pull_request_id:
+          type: string
+        user_id:
+          type: string
+        verdict:
+          $ref: '#/components/schemas/ReviewVerdict'
+        body:
+          type: string
+        createdAt:
+          type: string
+          format: date-time
+    ReviewerState:
+      type: object
+      required: [ user_id, state ]
+      properties:
+        user_id:
+          type: string
+        state:
+          type: string
+          enum: [PENDING, APPROVED, CHANGES_REQUESTED, COMMENTED]
+          description: >
+            Последний вердикт APPROVED или CHANGES_REQUESTED; более поздний COMMENTED его не отменяет.
+            COMMENTED, если ревьювер только комментировал; PENDING, если вердикта ещё нет
+        reviewedAt:
+          type: string
+          format: date-time
+          description: Время вердикта, определившего состояние; отсутствует у PENDING
+    ApprovalStatus:
+      type: object
+      required: [ required_approvals, approved_by, missing_approvals, pending_reviewers, changes_requested_by ]
+      properties:
+        required_approvals:
+          type: integer
+        approved_by:
+          type: array
+          items:
+            type: string
+        missing_approvals:
+          type: integer
+          description: Сколько одобрений не хватает для слияния
+        pending_reviewers:
+          type: array
+          items:
+            type: string
+          description: Назначенные ревьюверы без вердикта или только с вердиктами COMMENTED
+        changes_requested_by:
+          type: array
+          items:
+            type: string
+          description: Ревьюверы, чей последний вердикт без учёта COMMENTED — CHANGES_REQUESTED; пока они есть, слияние запрещено
+    MergeOverride:
+      type: object
+      required: [ id, pull_request_id, user_id, reason, required_approvals, approved_by, changes_requested_by, createdAt ]
+      properties:
+        id:
+          type: integer
+          format: int64
+        pull_request_id:
+          type: string
+        user_id:
+          type: string
+          description: Администратор, выполнивший принудительное слияние
+        reason:
+          type: string
+        required_approvals:
+          type: integer
+        approved_by:
+          type: array
+          items:
+            type: string
+        changes_requested_by:
+          type: array
+          items:
+            type: string
+        createdAt:
+          type: string
+          format: date-time
+    ReviewDecline:
+      type: object
+      required: [ id, pull_request_id, user_id, reason, createdAt ]
+      properties:
+        id:
+          type: integer
+          format: int64
+        pull_request_id:
+          type: string
+        user_id:
+          type: string
+        reason:
+          $ref: '#/components/schemas/DeclineReason'
+        comment:
+          type: string
+        replaced_by:
+          type: string
+          description: Отсутствует, если замену найти не удалось и ревьювер просто снят
+        createdAt:
+          type: string
+          format: date-time
+    ReviewerStats:
+      type: object
+      required: [ user_id, username, is_active, open_reviews, declines, declines_by_reason ]
+      properties:
+        user_id:
+          type: string
+        username:
+          type: string
+        is_active:
+          type: boolean
+        open_reviews:
+          type: integer
+        declines:
+          type: integer
+        declines_by_reason:
+          type: object
+          additionalProperties:
+            type: integer
+    ReviewHandoff:
+      type: object
+      required: [ pull_request_id, outcome ]
+      properties:
+        pull_request_id:
+          type: string
+        from_user_id:
+          type: string
+        outcome:
+          type: string
+          enum: [REASSIGNED, REMOVED]
+        replaced_by:
+          type: string
     PullRequestShort:
       type: object
       required: [ pull_request_id, pull_request_name, author_id, status]
@@ -123,7 +704,12 @@ components:
           type: string
         status:
           type: string
-          enum: [OPEN, MERGED]
+          enum: [DRAFT, OPEN, MERGED, CLOSED]
+        reviewers:
+          type: array
+          items:
+            $ref: '#/components/schemas/ReviewerState'
+          description: Состояние каждого назначенного ревьювера
 
 paths:
   /team/add:
@@ -138,13 +724,21 @@ paths:
               $ref: '#/components/schemas/Team'
             example:
               team_name: payments
+              reviewer_strategy: ROUND_ROBIN
+              fallback_teams: [backend]
               members:
                 - user_id: u1
                   username: Alice
                   is_active: true
+                  level: SENIOR
+                  timezone: Europe/Moscow
+                  work_start: "10:00"
+                  work_end: "19:00"
+                  skills: [go, postgres]
                 - user_id: u2
                   username: Bob
                   is_active: true
+                  skills: [frontend]
       responses:
         '201':
           description: Команда создана
@@ -158,6 +752,7 @@ paths:
               example:
                 team:
                   team_name: backend
+                  reviewer_strategy: LEAST_LOADED
                   members:
                     - user_id: u1
                       username: Alice
@@ -166,14 +761,21 @@ paths:
                       username: Bob
                       is_active: true
         '400':
-          description: Команда уже существует
+          description: Команда уже существует или некорректное расписание участника
           content:
             application/json:
               schema: { $ref: '#/components/schemas/ErrorResponse' }
-              example:
-                error:
-                  code: TEAM_EXISTS
-                  message: team_name already exists
+              examples:
+                teamExists:
+                  value:
+                    error:
+                      code: TEAM_EXISTS
+                      message: team_name already exists
+                invalidSchedule:
+                  value:
+                    error:
+                      code: INVALID_SCHEDULE
+                      message: "user u1: unknown timezone \"Mars/Olympus\""
 
   /team/get:
     get:
@@ -190,6 +792,7 @@ paths:
                 $ref: '#/components/schemas/Team'
               example:
                 team_name: backend
+                reviewer_strategy: LEAST_LOADED
                 members:
                   - user_id: u1
                     username: Alice
@@ -203,66 +806,257 @@ paths:
             application/json:
               schema: { $ref: '#/components/schemas/ErrorResponse' }
 
-  /users/setIsActive:
+  /team/policy/set:
     post:
-      tags: [Users]
-      summary: Установить флаг активности пользователя
+      tags: [Teams]
+      summary: Обновить политику ревью команды (переданные поля перезаписываются)
       requestBody:
         required: true
         content:
           application/json:
             schema:
               type: object
-              required: [ user_id, is_active ]
+              required: [ team_name ]
               properties:
-                user_id:
+                team_name:
                   type: string
-                is_active:
+                min_reviewers:
+                  type: integer
+                max_reviewers:
+                  type: integer
+                allow_cross_team:
                   type: boolean
+                require_senior:
+                  type: boolean
+                working_hours_mode:
+                  $ref: '#/components/schemas/WorkingHoursMode'
+                start_within_hours:
+                  type: integer
+                max_open_reviews:
+                  type: integer
+                required_approvals:
+                  type: integer
             example:
-              user_id: u2
-              is_active: false
+              team_name: platform
+              min_reviewers: 2
+              max_reviewers: 3
+              allow_cross_team: false
+              require_senior: true
+              working_hours_mode: STRICT
+              start_within_hours: 2
+              max_open_reviews: 5
+              required_approvals: 2
       responses:
         '200':
-          description: Обновлённый пользователь
+          description: Действующая политика
           content:
             application/json:
               schema:
                 type: object
                 properties:
-                  user:
-                    $ref: '#/components/schemas/User'
+                  policy:
+                    $ref: '#/components/schemas/TeamPolicy'
+        '400':
+          description: Некорректная политика
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
               example:
-                user:
-                  user_id: u2
-                  username: Bob
-                  team_name: backend
-                  is_active: false
+                error: { code: INVALID_POLICY, message: max_reviewers must not be less than min_reviewers }
         '404':
-          description: Пользователь не найден
+          description: Команда не найдена
           content:
             application/json:
               schema: { $ref: '#/components/schemas/ErrorResponse' }
 
-  /pullRequest/create:
-    post:
-      tags: [PullRequests]
-      summary: Создать PR и автоматически назначить до 2 ревьюверов из команды автора
-      requestBody:
-        required: true
-        content:
-          application/json:
-            schema:
-              type: object
-              required: [ pull_request_id, pull_request_name, author_id ]
+  /team/policy/get:
+    get:
+      tags: [Teams]
+      summary: Получить политику ревью команды (значения по умолчанию, если не задана)
+      parameters:
+        - $ref: '#/components/parameters/TeamNameQuery'
+      responses:
+        '200':
+          description: Действующая политика
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  policy:
+                    $ref: '#/components/schemas/TeamPolicy'
+        '404':
+          description: Команда не найдена
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /team/deactivateMembers:
+    post:
+      tags: [Teams]
+      summary: Массово деактивировать участников команды и перераспределить их открытые ревью
+      description: >
+        Без user_ids деактивируется вся команда. Открытые ревью деактивированных пользователей
+        передаются наименее загруженным подходящим активным ревьюверам команды, а при разрешённом
+        cross-team — и резервных команд. Если кандидата нет, ревьювер снимается с PR.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ team_name ]
+              properties:
+                team_name:
+                  type: string
+                user_ids:
+                  type: array
+                  items:
+                    type: string
+            example:
+              team_name: backend
+              user_ids: [u2, u3]
+      responses:
+        '200':
+          description: Отчёт по затронутым PR
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [ team_name, deactivated, pull_requests ]
+                properties:
+                  team_name:
+                    type: string
+                  deactivated:
+                    type: array
+                    items:
+                      type: string
+                  pull_requests:
+                    type: array
+                    items:
+                      type: object
+                      required: [ pull_request_id, handoffs, under_reviewed ]
+                      properties:
+                        pull_request_id:
+                          type: string
+                        under_reviewed:
+                          type: boolean
+                        handoffs:
+                          type: array
+                          items:
+                            type: object
+                            required: [ pull_request_id, outcome ]
+                            properties:
+                              pull_request_id:
+                                type: string
+                              from_user_id:
+                                type: string
+                              outcome:
+                                type: string
+                                enum: [REASSIGNED, REMOVED]
+                              replaced_by:
+                                type: string
+        '404':
+          description: Команда не найдена или пользователь не состоит в команде
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /users/setIsActive:
+    post:
+      tags: [Users]
+      summary: Установить флаг активности пользователя
+      description: >
+        При активации открытые PR команды пользователя дополняются ревьюверами до max_reviewers.
+        При деактивации с reassign_open_reviews=true открытые ревью пользователя переназначаются
+        по тем же правилам, что и /pullRequest/reassign, а при отсутствии кандидатов снимаются.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ user_id, is_active ]
+              properties:
+                user_id:
+                  type: string
+                is_active:
+                  type: boolean
+                reassign_open_reviews:
+                  type: boolean
+                  default: false
+            example:
+              user_id: u2
+              is_active: false
+              reassign_open_reviews: true
+      responses:
+        '200':
+          description: Обновлённый пользователь
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  user:
+                    $ref: '#/components/schemas/User'
+                  affected_pull_requests:
+                    type: array
+                    description: Только при деактивации с reassign_open_reviews=true
+                    items:
+                      $ref: '#/components/schemas/ReviewHandoff'
+              example:
+                user:
+                  user_id: u2
+                  username: Bob
+                  team_name: backend
+                  is_active: false
+                affected_pull_requests:
+                  - pull_request_id: pr-1001
+                    outcome: REASSIGNED
+                    replaced_by: u5
+        '404':
+          description: Пользователь не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /pullRequest/create:
+    post:
+      tags: [PullRequests]
+      summary: Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ pull_request_id, pull_request_name, author_id ]
               properties:
                 pull_request_id: { type: string }
                 pull_request_name: { type: string }
                 author_id: { type: string }
+                changed_files:
+                  type: array
+                  items:
+                    type: string
+                  description: Изменённые пути; ревьюверы сначала выбираются из владельцев этих путей
+                labels:
+                  type: array
+                  items:
+                    type: string
+                  description: Метки PR; предпочтение отдаётся кандидатам с совпадающими навыками, одно место остаётся за случайным выбором
+                queue_if_full:
+                  type: boolean
+                  description: Если все кандидаты достигли лимита открытых ревью, создать PR с under_reviewed=true вместо ошибки CAPACITY_EXHAUSTED; ревьюверы будут добавлены после освобождения
+                draft:
+                  type: boolean
+                  description: Создать черновик (DRAFT) без ревьюверов; ревьюверы назначаются при /pullRequest/markReady
             example:
               pull_request_id: pr-1001
               pull_request_name: Add search
               author_id: u1
+              changed_files: [pkg/search/index.go, docs/search.md]
+              labels: [go, postgres]
       responses:
         '201':
           description: PR создан
@@ -279,6 +1073,7 @@ paths:
                   pull_request_name: Add search
                   author_id: u1
                   status: OPEN
+                  labels: [go, postgres]
                   assigned_reviewers: [u2, u3]
         '404':
           description: Автор/команда не найдены
@@ -286,140 +1081,1237 @@ paths:
             application/json:
               schema: { $ref: '#/components/schemas/ErrorResponse' }
         '409':
-          description: PR уже существует
+          description: PR уже существует или все кандидаты достигли лимита открытых ревью
           content:
             application/json:
               schema: { $ref: '#/components/schemas/ErrorResponse' }
-              example:
-                error: { code: PR_EXISTS, message: PR id already exists }
+              examples:
+                exists:
+                  value:
+                    error: { code: PR_EXISTS, message: PR id already exists }
+                capacity:
+                  value:
+                    error: { code: CAPACITY_EXHAUSTED, message: all candidate reviewers are at capacity }
 
-  /pullRequest/merge:
+  /pullRequest/previewAssignment:
     post:
       tags: [PullRequests]
-      summary: Пометить PR как MERGED (идемпотентная операция)
+      summary: Предпросмотр назначения ревьюверов без создания PR
+      description: Выполняет тот же подбор, что и /pullRequest/create, но ничего не сохраняет (включая курсор ROUND_ROBIN). Случайные стратегии могут дать другой выбор при реальном создании.
       requestBody:
         required: true
         content:
           application/json:
             schema:
               type: object
-              required: [ pull_request_id ]
+              required: [ author_id ]
               properties:
-                pull_request_id: { type: string }
+                pull_request_id:
+                  type: string
+                  description: Необязателен; используется только для правила NO_REPEAT
+                author_id: { type: string }
+                changed_files:
+                  type: array
+                  items:
+                    type: string
+                labels:
+                  type: array
+                  items:
+                    type: string
             example:
-              pull_request_id: pr-1001
+              author_id: u1
+              changed_files: [pkg/search/index.go]
+              labels: [go]
       responses:
         '200':
-          description: PR в состоянии MERGED
+          description: Предполагаемое назначение
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  preview:
+                    $ref: '#/components/schemas/AssignmentPreview'
+              example:
+                preview:
+                  author_id: u1
+                  strategy: LEAST_LOADED
+                  labels: [go]
+                  proposed_reviewers: [u2]
+                  under_reviewed: true
+                  candidates:
+                    - { user_id: u2, source: TEAM, team_name: backend, eligible: true, selected: true }
+                    - { user_id: u1, eligible: false, reason: AUTHOR, selected: false }
+                    - { user_id: u3, eligible: false, reason: AT_CAPACITY, selected: false }
+                  rule_violations: []
+                  warnings:
+                    - { code: CAPACITY_EXHAUSTED, message: candidates are at capacity; creating the PR fails unless queue_if_full is set }
+                    - { code: UNDERSTAFFED, message: 1 of 2 required reviewers available }
+        '404':
+          description: Автор/команда не найдены
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /pullRequest/get:
+    get:
+      tags: [PullRequests]
+      summary: Получить PR с метками, ревьюверами и их вердиктами
+      parameters:
+        - $ref: '#/components/parameters/PullRequestIdQuery'
+      responses:
+        '200':
+          description: PR
           content:
             application/json:
               schema:
                 type: object
+                required: [ pr ]
                 properties:
                   pr:
                     $ref: '#/components/schemas/PullRequest'
+                  override:
+                    $ref: '#/components/schemas/MergeOverride'
               example:
                 pr:
                   pull_request_id: pr-1001
                   pull_request_name: Add search
+                  description: Full-text search over products
                   author_id: u1
-                  status: MERGED
+                  status: OPEN
+                  metadata: { ticket: SRCH-12 }
+                  labels: [backend, search]
                   assigned_reviewers: [u2, u3]
-                  mergedAt: 2025-10-24T12:34:56Z
+                  reviewers:
+                    - user_id: u2
+                      state: APPROVED
+                      reviewedAt: 2025-10-24T12:34:56Z
+                    - user_id: u3
+                      state: PENDING
+                  under_reviewed: false
         '404':
           description: PR не найден
           content:
             application/json:
               schema: { $ref: '#/components/schemas/ErrorResponse' }
 
-  /pullRequest/reassign:
+  /pullRequest/update:
     post:
       tags: [PullRequests]
-      summary: Переназначить конкретного ревьювера на другого из его команды
+      summary: Изменить название, описание, метки или метаданные PR
+      description: >
+        Меняются только переданные поля. labels заменяют текущий набор целиком;
+        metadata объединяется с текущими метаданными, ключ со значением null удаляется.
+        Ревьюверы не переназначаются. Слитый PR изменить нельзя (PR_MERGED).
       requestBody:
         required: true
         content:
           application/json:
             schema:
               type: object
-              required: [ pull_request_id, old_user_id ]
+              required: [ pull_request_id ]
               properties:
                 pull_request_id: { type: string }
-                old_user_id: { type: string }
+                pull_request_name: { type: string }
+                description: { type: string }
+                labels:
+                  type: array
+                  items:
+                    type: string
+                metadata:
+                  type: object
+                  additionalProperties:
+                    type: string
+                    nullable: true
             example:
               pull_request_id: pr-1001
-              old_reviewer_id: u2
+              pull_request_name: Add search filters
+              labels: [backend, search]
+              metadata: { ticket: SRCH-12, reviewed_by_qa: null }
       responses:
         '200':
-          description: Переназначение выполнено
+          description: Обновлённый PR
           content:
             application/json:
               schema:
                 type: object
-                required: [pr, replaced_by]
                 properties:
                   pr:
                     $ref: '#/components/schemas/PullRequest'
-                  replaced_by:
-                    type: string
-                    description: user_id нового ревьювера
+        '400':
+          description: Пустое название или ключ метаданных
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+              example:
+                error: { code: INVALID_UPDATE, message: pull_request_name must not be empty }
+        '404':
+          description: PR не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+        '409':
+          description: PR уже слит
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+              example:
+                error: { code: PR_MERGED, message: cannot update merged PR }
+
+  /pullRequest/merge:
+    post:
+      tags: [PullRequests]
+      summary: Пометить PR как MERGED (идемпотентная операция)
+      description: >
+        PR сливается, только если его одобрило не меньше назначенных ревьюверов, чем требует required_approvals политики команды автора,
+        и ни у кого из них последний вердикт без учёта COMMENTED не CHANGES_REQUESTED. Администратор может слить PR в обход
+        этой проверки с force=true и override_reason, передав свой токен из переменной AdminTokens в заголовке X-Admin-Token;
+        обход сохраняется от имени user_id, которому принадлежит токен, и возвращается в поле override.
+        После слияния открытые PR с under_reviewed=true дополняются ревьюверами, у которых освободилась ёмкость.
+        Закрытый PR слить нельзя (PR_CLOSED).
+      parameters:
+        - name: X-Admin-Token
+          in: header
+          required: false
+          schema:
+            type: string
+          description: Токен администратора из переменной AdminTokens; обязателен при force=true
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ pull_request_id ]
+              properties:
+                pull_request_id: { type: string }
+                force:
+                  type: boolean
+                  description: Слить без требуемых одобрений (только с токеном администратора)
+                override_reason:
+                  type: string
+                  description: Причина принудительного слияния; обязательна при force=true
+            examples:
+              regular:
+                value:
+                  pull_request_id: pr-1001
+              forced:
+                value:
+                  pull_request_id: pr-1001
+                  force: true
+                  override_reason: production incident hotfix
+      responses:
+        '200':
+          description: PR в состоянии MERGED
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  pr:
+                    $ref: '#/components/schemas/PullRequest'
+                  override:
+                    $ref: '#/components/schemas/MergeOverride'
               example:
                 pr:
                   pull_request_id: pr-1001
                   pull_request_name: Add search
                   author_id: u1
-                  status: OPEN
-                  assigned_reviewers: [u3, u5]
-                replaced_by: u5
+                  status: MERGED
+                  assigned_reviewers: [u2, u3]
+                  mergedAt: 2025-10-24T12:34:56Z
+        '400':
+          description: Для force=true не указана override_reason
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+              example:
+                error: { code: INVALID_OVERRIDE, message: force merge requires override_reason }
+        '403':
+          description: Для force=true не передан действующий токен администратора
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+              example:
+                error: { code: FORBIDDEN, message: force merge requires a valid admin token }
         '404':
-          description: PR или пользователь не найден
+          description: PR не найден
           content:
             application/json:
               schema: { $ref: '#/components/schemas/ErrorResponse' }
         '409':
-          description: Нарушение доменных правил переназначения
+          description: PR закрыт, является черновиком или не набрал одобрений
           content:
             application/json:
               schema: { $ref: '#/components/schemas/ErrorResponse' }
               examples:
-                merged:
-                  summary: Нельзя менять после MERGED
+                closed:
                   value:
-                    error: { code: PR_MERGED, message: cannot reassign on merged PR }
-                notAssigned:
-                  summary: Пользователь не был назначен ревьювером
+                    error: { code: PR_CLOSED, message: cannot merge closed PR }
+                draft:
                   value:
-                    error: { code: NOT_ASSIGNED, message: reviewer is not assigned to this PR }
-                noCandidate:
-                  summary: Нет доступных кандидатов
+                    error: { code: PR_DRAFT, message: cannot merge draft PR }
+                notApproved:
                   value:
-                    error: { code: NO_CANDIDATE, message: no active replacement candidate in team }
+                    error:
+                      code: NOT_APPROVED
+                      message: 1 of 2 required approvals missing; changes requested by u3
+                      details:
+                        required_approvals: 2
+                        approved_by: [u2]
+                        missing_approvals: 1
+                        pending_reviewers: []
+                        changes_requested_by: [u3]
 
-  /users/getReview:
-    get:
-      tags: [Users]
-      summary: Получить PR'ы, где пользователь назначен ревьювером
-      parameters:
-        - $ref: '#/components/parameters/UserIdQuery'
+  /pullRequest/markReady:
+    post:
+      tags: [PullRequests]
+      summary: Перевести черновик в OPEN и назначить ревьюверов (идемпотентная операция)
+      description: Ревьюверы подбираются так же, как при /pullRequest/create; метки берутся из черновика, изменённые пути передаются заново.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ pull_request_id ]
+              properties:
+                pull_request_id: { type: string }
+                changed_files:
+                  type: array
+                  items:
+                    type: string
+                queue_if_full:
+                  type: boolean
+            example:
+              pull_request_id: pr-1001
+              changed_files: [pkg/search/index.go]
       responses:
         '200':
-          description: Список PR'ов пользователя
+          description: PR в состоянии OPEN
           content:
             application/json:
               schema:
                 type: object
-                required: [ user_id, pull_requests ]
                 properties:
-                  user_id:
-                    type: string
-                  pull_requests:
-                    type: array
+                  pr:
+                    $ref: '#/components/schemas/PullRequest'
+              example:
+                pr:
+                  pull_request_id: pr-1001
+                  pull_request_name: Add search
+                  author_id: u1
+                  status: OPEN
+                  assigned_reviewers: [u2, u3]
+        '404':
+          description: PR не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+        '409':
+          description: PR уже слит или закрыт, либо все кандидаты достигли лимита открытых ревью
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /pullRequest/close:
+    post:
+      tags: [PullRequests]
+      summary: Закрыть открытый PR или черновик без слияния (идемпотентная операция)
+      description: Закрытый PR не учитывается в нагрузке ревьюверов и не показывается в /users/getReview; после закрытия PR с under_reviewed=true дополняются ревьюверами.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ pull_request_id ]
+              properties:
+                pull_request_id: { type: string }
+            example:
+              pull_request_id: pr-1001
+      responses:
+        '200':
+          description: PR в состоянии CLOSED
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  pr:
+                    $ref: '#/components/schemas/PullRequest'
+              example:
+                pr:
+                  pull_request_id: pr-1001
+                  pull_request_name: Add search
+                  author_id: u1
+                  status: CLOSED
+                  assigned_reviewers: [u2, u3]
+                  closedAt: 2025-10-24T12:34:56Z
+        '404':
+          description: PR не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+        '409':
+          description: PR уже слит
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /pullRequest/reopen:
+    post:
+      tags: [PullRequests]
+      summary: Переоткрыть закрытый PR (идемпотентная операция)
+      description: >
+        PR, закрытый в статусе DRAFT, возвращается в DRAFT и по-прежнему требует /pullRequest/markReady.
+        Иначе ревьюверы, ставшие неактивными или отсутствующие, заменяются так же, как при
+        /team/deactivateMembers, или снимаются, если замены нет; замены сохраняются вместе с переоткрытием.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ pull_request_id ]
+              properties:
+                pull_request_id: { type: string }
+            example:
+              pull_request_id: pr-1001
+      responses:
+        '200':
+          description: PR в состоянии OPEN или DRAFT
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [ pr, replaced_reviewers ]
+                properties:
+                  pr:
+                    $ref: '#/components/schemas/PullRequest'
+                  replaced_reviewers:
+                    type: array
                     items:
-                      $ref: '#/components/schemas/PullRequestShort'
+                      $ref: '#/components/schemas/ReviewHandoff'
               example:
-                user_id: u2
-                pull_requests:
+                pr:
+                  pull_request_id: pr-1001
+                  pull_request_name: Add search
+                  author_id: u1
+                  status: OPEN
+                  assigned_reviewers: [u3, u5]
+                replaced_reviewers:
                   - pull_request_id: pr-1001
-                    pull_request_name: Add search
-                    author_id: u1
-                    status: OPEN
+                    from_user_id: u2
+                    outcome: REASSIGNED
+                    replaced_by: u5
+        '404':
+          description: PR не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+        '409':
+          description: PR уже слит
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /pullRequest/reassign:
+    post:
+      tags: [PullRequests]
+      summary: Переназначить конкретного ревьювера на другого из команды автора PR или её резервных команд
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ pull_request_id, old_user_id ]
+              properties:
+                pull_request_id: { type: string }
+                old_user_id: { type: string }
+                new_reviewer_id:
+                  type: string
+                  description: >
+                    Явно выбранный новый ревьювер. Должен быть активен, не быть автором или уже назначенным
+                    ревьювером и проходить политику команды; иначе возвращается NO_CANDIDATE или CAPACITY_EXHAUSTED.
+                    Без него замена выбирается стратегией команды.
+            example:
+              pull_request_id: pr-1001
+              old_reviewer_id: u2
+      responses:
+        '200':
+          description: Переназначение выполнено
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [pr, replaced_by]
+                properties:
+                  pr:
+                    $ref: '#/components/schemas/PullRequest'
+                  replaced_by:
+                    type: string
+                    description: user_id нового ревьювера
+              example:
+                pr:
+                  pull_request_id: pr-1001
+                  pull_request_name: Add search
+                  author_id: u1
+                  status: OPEN
+                  assigned_reviewers: [u3, u5]
+                replaced_by: u5
+        '404':
+          description: PR или пользователь не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+        '409':
+          description: Нарушение доменных правил переназначения
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+              examples:
+                merged:
+                  summary: Нельзя менять после MERGED
+                  value:
+                    error: { code: PR_MERGED, message: cannot reassign on merged PR }
+                notAssigned:
+                  summary: Пользователь не был назначен ревьювером
+                  value:
+                    error: { code: NOT_ASSIGNED, message: reviewer is not assigned to this PR }
+                noCandidate:
+                  summary: Нет доступных кандидатов
+                  value:
+                    error: { code: NO_CANDIDATE, message: no active replacement candidate in team }
+                capacity:
+                  summary: Все кандидаты достигли лимита открытых ревью
+                  value:
+                    error: { code: CAPACITY_EXHAUSTED, message: all replacement candidates are at capacity }
+                chosenAssigned:
+                  summary: Выбранный ревьювер уже назначен на PR
+                  value:
+                    error: { code: NO_CANDIDATE, message: new reviewer is already assigned to this PR }
+                noSenior:
+                  summary: Заменяется единственный старший ревьювер, а старших кандидатов нет
+                  value:
+                    error: { code: NO_CANDIDATE, message: no senior replacement candidate in team or fallback teams }
+                reviewSubmitted:
+                  summary: Ревьювер уже оставил вердикт, ревью начато
+                  value:
+                    error: { code: REVIEW_SUBMITTED, message: reviewer already submitted a verdict on this PR }
+
+  /pullRequest/addReviewer:
+    post:
+      tags: [PullRequests]
+      summary: Добавить выбранного ревьювера к открытому PR
+      description: >
+        Ревьювер проверяется так же, как при переназначении с new_reviewer_id. Число ревьюверов
+        не может превысить max_reviewers политики команды автора.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ pull_request_id, user_id ]
+              properties:
+                pull_request_id: { type: string }
+                user_id: { type: string }
+            example:
+              pull_request_id: pr-1001
+              user_id: u4
+      responses:
+        '200':
+          description: Ревьювер добавлен
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  pr:
+                    $ref: '#/components/schemas/PullRequest'
+        '404':
+          description: PR или пользователь не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+        '409':
+          description: PR уже слит, достигнут максимум ревьюверов или пользователь не подходит
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+              examples:
+                limit:
+                  value:
+                    error: { code: REVIEWER_LIMIT, message: PR already has the maximum number of reviewers }
+                author:
+                  value:
+                    error: { code: NO_CANDIDATE, message: new reviewer is the PR author }
+
+  /pullRequest/removeReviewer:
+    post:
+      tags: [PullRequests]
+      summary: Снять ревьювера с открытого PR
+      description: >
+        Если после снятия ревьюверов меньше min_reviewers, PR в той же транзакции дополняется до min_reviewers
+        (снятый ревьювер не выбирается повторно). С черновика ревьюверов снять нельзя (PR_DRAFT).
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ pull_request_id, user_id ]
+              properties:
+                pull_request_id: { type: string }
+                user_id: { type: string }
+            example:
+              pull_request_id: pr-1001
+              user_id: u4
+      responses:
+        '200':
+          description: Ревьювер снят
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  pr:
+                    $ref: '#/components/schemas/PullRequest'
+        '404':
+          description: PR не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+        '409':
+          description: PR уже слит, закрыт, является черновиком или пользователь не назначен ревьювером
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /pullRequest/decline:
+    post:
+      tags: [PullRequests]
+      summary: Отказаться от ревью с указанием причины
+      description: Назначенный ревьювер отказывается от ревью. Замена подбирается так же, как при /pullRequest/reassign; если заменить некем, ревьювер просто снимается. Отказ сохраняется и учитывается в /stats/reviewers.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ pull_request_id, user_id, reason ]
+              properties:
+                pull_request_id: { type: string }
+                user_id: { type: string }
+                reason:
+                  $ref: '#/components/schemas/DeclineReason'
+                comment: { type: string }
+            example:
+              pull_request_id: pr-1001
+              user_id: u2
+              reason: NO_CONTEXT
+              comment: never touched the search module
+      responses:
+        '200':
+          description: Отказ принят
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [ pr, decline ]
+                properties:
+                  pr:
+                    $ref: '#/components/schemas/PullRequest'
+                  decline:
+                    $ref: '#/components/schemas/ReviewDecline'
+        '400':
+          description: Неизвестная причина отказа
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+              example:
+                error: { code: INVALID_DECLINE, message: unknown decline reason }
+        '404':
+          description: PR или пользователь не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+        '409':
+          description: PR уже слит или пользователь не назначен ревьювером
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /pullRequest/submitReview:
+    post:
+      tags: [PullRequests]
+      summary: Оставить вердикт по ревью
+      description: Назначенный ревьювер открытого PR фиксирует вердикт. Предыдущие вердикты сохраняются в истории; состоянием ревьювера считается последний APPROVED или CHANGES_REQUESTED, а COMMENTED его не отменяет.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ pull_request_id, user_id, verdict ]
+              properties:
+                pull_request_id: { type: string }
+                user_id: { type: string }
+                verdict:
+                  $ref: '#/components/schemas/ReviewVerdict'
+                body: { type: string }
+            example:
+              pull_request_id: pr-1001
+              user_id: u2
+              verdict: CHANGES_REQUESTED
+              body: please cover the empty query case
+      responses:
+        '201':
+          description: Вердикт сохранён
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [ pr, review ]
+                properties:
+                  pr:
+                    $ref: '#/components/schemas/PullRequest'
+                  review:
+                    $ref: '#/components/schemas/Review'
+        '400':
+          description: Неизвестный вердикт
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+              example:
+                error: { code: INVALID_VERDICT, message: unknown review verdict }
+        '404':
+          description: PR не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+        '409':
+          description: PR не открыт или пользователь не назначен ревьювером
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /pullRequest/reviews:
+    get:
+      tags: [PullRequests]
+      summary: Получить историю вердиктов по PR
+      parameters:
+        - $ref: '#/components/parameters/PullRequestIdQuery'
+      responses:
+        '200':
+          description: Вердикты в порядке создания
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [ pull_request_id, reviews ]
+                properties:
+                  pull_request_id:
+                    type: string
+                  reviews:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/Review'
+        '404':
+          description: PR не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /pullRequest/assignmentTrace:
+    get:
+      tags: [PullRequests]
+      summary: Получить историю решений о назначении ревьюверов для PR
+      parameters:
+        - $ref: '#/components/parameters/PullRequestIdQuery'
+      responses:
+        '200':
+          description: Записи о назначениях в порядке создания
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [ pull_request_id, traces ]
+                properties:
+                  pull_request_id:
+                    type: string
+                  traces:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/AssignmentTrace'
+              example:
+                pull_request_id: pr-1001
+                traces:
+                  - id: 1
+                    pull_request_id: pr-1001
+                    action: CREATE
+                    strategy: LEAST_LOADED
+                    seed: 1729170000000000000
+                    candidates: [u2, u3, u4]
+                    excluded:
+                      - user_id: u1
+                        reason: AUTHOR
+                      - user_id: u5
+                        reason: INACTIVE
+                    selected: [u2, u4]
+                    createdAt: 2025-10-24T12:00:00Z
+        '404':
+          description: PR не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /users/getReview:
+    get:
+      tags: [Users]
+      summary: Получить PR'ы, где пользователь назначен ревьювером
+      parameters:
+        - $ref: '#/components/parameters/UserIdQuery'
+      responses:
+        '200':
+          description: Список PR'ов пользователя
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [ user_id, pull_requests ]
+                properties:
+                  user_id:
+                    type: string
+                  pull_requests:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/PullRequestShort'
+              example:
+                user_id: u2
+                pull_requests:
+                  - pull_request_id: pr-1001
+                    pull_request_name: Add search
+                    author_id: u1
+                    status: OPEN
+
+  /users/absence/add:
+    post:
+      tags: [Users]
+      summary: Запланировать отсутствие пользователя
+      description: На время отсутствия пользователь не назначается ревьювером; с его начала открытые ревью передаются другим ревьюверам.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ user_id, startsAt, endsAt ]
+              properties:
+                user_id:
+                  type: string
+                startsAt:
+                  type: string
+                  format: date-time
+                endsAt:
+                  type: string
+                  format: date-time
+                reason:
+                  type: string
+            example:
+              user_id: u2
+              startsAt: 2025-11-03T00:00:00Z
+              endsAt: 2025-11-10T00:00:00Z
+              reason: vacation
+      responses:
+        '201':
+          description: Отсутствие запланировано
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  absence:
+                    $ref: '#/components/schemas/Absence'
+        '400':
+          description: Некорректный период
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+              example:
+                error: { code: INVALID_ABSENCE, message: endsAt must be after startsAt }
+        '404':
+          description: Пользователь не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /users/absence/delete:
+    post:
+      tags: [Users]
+      summary: Отменить отсутствие пользователя
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ absence_id ]
+              properties:
+                absence_id:
+                  type: integer
+                  format: int64
+      responses:
+        '200':
+          description: Отсутствие отменено
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  absence_id:
+                    type: integer
+                    format: int64
+        '404':
+          description: Отсутствие не найдено
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /users/absence/list:
+    get:
+      tags: [Users]
+      summary: Получить отсутствия пользователя
+      parameters:
+        - $ref: '#/components/parameters/UserIdQuery'
+      responses:
+        '200':
+          description: Список отсутствий
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [ user_id, absences ]
+                properties:
+                  user_id:
+                    type: string
+                  absences:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/Absence'
+        '404':
+          description: Пользователь не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /ownership/set:
+    post:
+      tags: [Ownership]
+      summary: Заменить правила владения путями
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ rules ]
+              properties:
+                rules:
+                  type: array
+                  items:
+                    $ref: '#/components/schemas/OwnershipRule'
+            example:
+              rules:
+                - pattern: "*"
+                  owners:
+                    - { type: TEAM, id: backend }
+                - pattern: /docs/
+                  owners:
+                    - { type: USER, id: u3 }
+      responses:
+        '200':
+          description: Сохранённые правила
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  rules:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/OwnershipRule'
+        '400':
+          description: Некорректное правило
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /ownership/import:
+    post:
+      tags: [Ownership]
+      summary: Загрузить правила владения из файла формата CODEOWNERS
+      description: "@org/team задаёт команду (имя после слэша), @user — пользователя. Правила заменяют текущие."
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ content ]
+              properties:
+                content:
+                  type: string
+            example:
+              content: "* @acme/backend\n/docs/ @u3\n"
+      responses:
+        '200':
+          description: Сохранённые правила
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  rules:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/OwnershipRule'
+        '400':
+          description: Файл не удалось разобрать
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+              example:
+                error: { code: INVALID_OWNERSHIP, message: "line 2: unsupported owner \"docs@example.com\"" }
+
+  /ownership/get:
+    get:
+      tags: [Ownership]
+      summary: Получить текущие правила владения путями
+      responses:
+        '200':
+          description: Правила в порядке применения
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  rules:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/OwnershipRule'
+
+  /rules/add:
+    post:
+      tags: [Rules]
+      summary: Добавить правило подбора ревьюверов
+      description: Нарушающие правила кандидаты назначаются, только если без них не набирается минимум ревьюверов; нарушения возвращаются в rule_violations.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ type ]
+              properties:
+                type:
+                  $ref: '#/components/schemas/RuleType'
+                author_id:
+                  type: string
+                reviewer_id:
+                  type: string
+                author_level:
+                  $ref: '#/components/schemas/UserLevel'
+                reviewer_level:
+                  $ref: '#/components/schemas/UserLevel'
+                  description: Только для PREFER; задаётся вместо author_id и reviewer_id
+            example:
+              type: AVOID
+              author_id: u1
+              reviewer_id: u2
+      responses:
+        '201':
+          description: Правило создано
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  rule:
+                    $ref: '#/components/schemas/ReviewerRule'
+        '400':
+          description: Некорректное правило
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+              example:
+                error: { code: INVALID_RULE, message: author_id and reviewer_id must differ }
+        '404':
+          description: Пользователь не найден
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /rules/delete:
+    post:
+      tags: [Rules]
+      summary: Удалить правило подбора ревьюверов
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ rule_id ]
+              properties:
+                rule_id:
+                  type: integer
+                  format: int64
+      responses:
+        '200':
+          description: Правило удалено
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  rule_id:
+                    type: integer
+                    format: int64
+        '404':
+          description: Правило не найдено
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /rules/list:
+    get:
+      tags: [Rules]
+      summary: Получить все правила подбора ревьюверов
+      responses:
+        '200':
+          description: Список правил
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  rules:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/ReviewerRule'
+
+  /rebalance/report:
+    get:
+      tags: [Rebalance]
+      summary: Пробный прогон перераспределения ревью (без изменений)
+      description: >
+        Показывает, какие открытые ревью были бы переданы от перегруженных участников команды
+        к менее загруженным. Ревью, назначенные раньше RebalanceMaxAge или с уже оставленным вердиктом, считаются начатыми и не переносятся.
+      parameters:
+        - name: team_name
+          in: query
+          required: false
+          schema:
+            type: string
+          description: Ограничить отчёт одной командой
+      responses:
+        '200':
+          description: Отчёт о планируемых переносах
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/RebalanceReport' }
+        '404':
+          description: Команда не найдена
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /rebalance/run:
+    post:
+      tags: [Rebalance]
+      summary: Перераспределить открытые ревью внутри команд
+      description: Выполняет те же переносы, что и /rebalance/report, и сохраняет запись о каждом из них.
+      requestBody:
+        required: false
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                team_name:
+                  type: string
+      responses:
+        '200':
+          description: Выполненные переносы
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/RebalanceReport' }
+        '404':
+          description: Команда не найдена
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
+
+  /rebalance/history:
+    get:
+      tags: [Rebalance]
+      summary: Последние переносы ревью
+      parameters:
+        - name: limit
+          in: query
+          required: false
+          schema:
+            type: integer
+            default: 50
+      responses:
+        '200':
+          description: Записи о переносах, новые первыми
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  reassignments:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/Reassignment'
+
+  /stats/reviewers:
+    get:
+      tags: [Stats]
+      summary: Статистика ревьюверов команды
+      description: Число открытых ревью и отказов от ревью (всего и по причинам) для каждого участника команды.
+      parameters:
+        - $ref: '#/components/parameters/TeamNameQuery'
+      responses:
+        '200':
+          description: Статистика команды
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [ team_name, reviewers ]
+                properties:
+                  team_name:
+                    type: string
+                  reviewers:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/ReviewerStats'
+              example:
+                team_name: backend
+                reviewers:
+                  - user_id: u2
+                    username: Bob
+                    is_active: true
+                    open_reviews: 3
+                    declines: 2
+                    declines_by_reason: { TOO_BUSY: 1, NO_CONTEXT: 1 }
+        '404':
+          description: Команда не найдена
+          content:
+            application/json:
+              schema: { $ref: '#/components/schemas/ErrorResponse' }
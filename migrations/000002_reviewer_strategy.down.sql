@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS team_round_robin_cursors;
+ALTER TABLE teams DROP COLUMN IF EXISTS reviewer_strategy;
\ No newline at end of file
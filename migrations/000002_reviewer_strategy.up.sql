@@ -0,0 +1,9 @@
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS reviewer_strategy VARCHAR(20) NOT NULL DEFAULT 'LEAST_LOADED'
+    CHECK (reviewer_strategy IN ('RANDOM', 'ROUND_ROBIN', 'LEAST_LOADED', 'WEIGHTED'));
+
+CREATE TABLE IF NOT EXISTS team_round_robin_cursors (
+    team_name VARCHAR(255) PRIMARY KEY,
+    last_user_id VARCHAR(255) NOT NULL,
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+    );
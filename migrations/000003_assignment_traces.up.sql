@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS assignment_traces (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('CREATE', 'REASSIGN')),
+    strategy VARCHAR(20) NOT NULL,
+    seed BIGINT NOT NULL,
+    candidates JSONB NOT NULL DEFAULT '[]',
+    excluded JSONB NOT NULL DEFAULT '[]',
+    selected JSONB NOT NULL DEFAULT '[]',
+    loads JSONB NOT NULL DEFAULT '{}',
+    cursors JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_assignment_traces_pull_request_id ON assignment_traces(pull_request_id);
@@ -0,0 +1,2 @@
+ALTER TABLE assignment_traces DROP COLUMN IF EXISTS pools;
+DROP TABLE IF EXISTS ownership_rules;
\ No newline at end of file
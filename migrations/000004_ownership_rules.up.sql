@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS ownership_rules (
+    position INT PRIMARY KEY,
+    pattern VARCHAR(1000) NOT NULL,
+    owners JSONB NOT NULL DEFAULT '[]'
+    );
+
+ALTER TABLE assignment_traces
+    ADD COLUMN IF NOT EXISTS pools JSONB NOT NULL DEFAULT '[]';
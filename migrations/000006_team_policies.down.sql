@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS under_reviewed;
+DROP TABLE IF EXISTS team_policies;
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS team_policies (
+    team_name VARCHAR(255) PRIMARY KEY,
+    min_reviewers INT NOT NULL CHECK (min_reviewers >= 0),
+    max_reviewers INT NOT NULL CHECK (max_reviewers >= 0),
+    allow_cross_team BOOLEAN NOT NULL DEFAULT true,
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE,
+    CHECK (max_reviewers >= min_reviewers)
+    );
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS under_reviewed BOOLEAN NOT NULL DEFAULT false;
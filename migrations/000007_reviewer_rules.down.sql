@@ -0,0 +1,2 @@
+ALTER TABLE assignment_traces DROP COLUMN IF EXISTS violations;
+DROP TABLE IF EXISTS reviewer_rules;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS reviewer_rules (
+    id BIGSERIAL PRIMARY KEY,
+    rule_type VARCHAR(20) NOT NULL CHECK (rule_type IN ('AVOID', 'PREFER', 'NO_REPEAT')),
+    author_id VARCHAR(255),
+    reviewer_id VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewer_id) REFERENCES users(user_id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_rules_author_id ON reviewer_rules(author_id);
+
+ALTER TABLE assignment_traces
+    ADD COLUMN IF NOT EXISTS violations JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,4 @@
+ALTER TABLE assignment_traces DROP COLUMN IF EXISTS random_pick;
+ALTER TABLE assignment_traces DROP COLUMN IF EXISTS labels;
+DROP TABLE IF EXISTS pr_labels;
+DROP TABLE IF EXISTS user_skills;
\ No newline at end of file
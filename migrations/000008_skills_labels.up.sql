@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS user_skills (
+    user_id VARCHAR(255) NOT NULL,
+    skill VARCHAR(100) NOT NULL,
+    PRIMARY KEY (user_id, skill),
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+    );
+
+CREATE TABLE IF NOT EXISTS pr_labels (
+    pull_request_id VARCHAR(255) NOT NULL,
+    label VARCHAR(100) NOT NULL,
+    PRIMARY KEY (pull_request_id, label),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+    );
+
+ALTER TABLE assignment_traces
+    ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS random_pick VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+ALTER TABLE assignment_traces DROP COLUMN IF EXISTS senior_pick;
+ALTER TABLE team_policies DROP COLUMN IF EXISTS require_senior;
+ALTER TABLE reviewer_rules DROP COLUMN IF EXISTS reviewer_level, DROP COLUMN IF EXISTS author_level;
+ALTER TABLE users DROP COLUMN IF EXISTS level;
\ No newline at end of file
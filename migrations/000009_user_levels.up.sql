@@ -0,0 +1,13 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS level VARCHAR(20) NOT NULL DEFAULT 'MIDDLE'
+    CHECK (level IN ('JUNIOR', 'MIDDLE', 'SENIOR', 'LEAD'));
+
+ALTER TABLE reviewer_rules
+    ADD COLUMN IF NOT EXISTS author_level VARCHAR(20) CHECK (author_level IN ('JUNIOR', 'MIDDLE', 'SENIOR', 'LEAD')),
+    ADD COLUMN IF NOT EXISTS reviewer_level VARCHAR(20) CHECK (reviewer_level IN ('JUNIOR', 'MIDDLE', 'SENIOR', 'LEAD'));
+
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS require_senior BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE assignment_traces
+    ADD COLUMN IF NOT EXISTS senior_pick VARCHAR(255) NOT NULL DEFAULT '';
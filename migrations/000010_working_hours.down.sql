@@ -0,0 +1,5 @@
+ALTER TABLE team_policies DROP COLUMN IF EXISTS start_within_hours;
+ALTER TABLE team_policies DROP COLUMN IF EXISTS working_hours_mode;
+ALTER TABLE users DROP COLUMN IF EXISTS work_end;
+ALTER TABLE users DROP COLUMN IF EXISTS work_start;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS work_start VARCHAR(5) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS work_end VARCHAR(5) NOT NULL DEFAULT '';
+
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS working_hours_mode VARCHAR(10) NOT NULL DEFAULT 'SOFT'
+    CHECK (working_hours_mode IN ('OFF', 'SOFT', 'STRICT')),
+    ADD COLUMN IF NOT EXISTS start_within_hours INT NOT NULL DEFAULT 0 CHECK (start_within_hours >= 0);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_pull_requests_under_reviewed;
+
+DELETE FROM assignment_traces WHERE action = 'TOP_UP';
+
+ALTER TABLE assignment_traces
+    DROP CONSTRAINT IF EXISTS assignment_traces_action_check;
+
+ALTER TABLE assignment_traces
+    ADD CONSTRAINT assignment_traces_action_check CHECK (action IN ('CREATE', 'REASSIGN'));
+
+ALTER TABLE team_policies DROP COLUMN IF EXISTS max_open_reviews;
+ALTER TABLE users DROP COLUMN IF EXISTS max_open_reviews;
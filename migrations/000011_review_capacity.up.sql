@@ -0,0 +1,13 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS max_open_reviews INT CHECK (max_open_reviews >= 0);
+
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS max_open_reviews INT NOT NULL DEFAULT 0 CHECK (max_open_reviews >= 0);
+
+ALTER TABLE assignment_traces
+    DROP CONSTRAINT IF EXISTS assignment_traces_action_check;
+
+ALTER TABLE assignment_traces
+    ADD CONSTRAINT assignment_traces_action_check CHECK (action IN ('CREATE', 'REASSIGN', 'TOP_UP'));
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_under_reviewed ON pull_requests(created_at) WHERE under_reviewed AND status = 'OPEN';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS reassignments;
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS assigned_at;
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+CREATE TABLE IF NOT EXISTS reassignments (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    team_name VARCHAR(255) NOT NULL,
+    from_user_id VARCHAR(255) NOT NULL,
+    to_user_id VARCHAR(255) NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_reassignments_pull_request_id ON reassignments(pull_request_id);
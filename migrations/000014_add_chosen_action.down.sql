@@ -0,0 +1,7 @@
+DELETE FROM assignment_traces WHERE action = 'ADD_CHOSEN';
+
+ALTER TABLE assignment_traces
+    DROP CONSTRAINT IF EXISTS assignment_traces_action_check;
+
+ALTER TABLE assignment_traces
+    ADD CONSTRAINT assignment_traces_action_check CHECK (action IN ('CREATE', 'REASSIGN', 'TOP_UP', 'REASSIGN_CHOSEN'));
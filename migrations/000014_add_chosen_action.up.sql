@@ -0,0 +1,5 @@
+ALTER TABLE assignment_traces
+    DROP CONSTRAINT IF EXISTS assignment_traces_action_check;
+
+ALTER TABLE assignment_traces
+    ADD CONSTRAINT assignment_traces_action_check CHECK (action IN ('CREATE', 'REASSIGN', 'TOP_UP', 'REASSIGN_CHOSEN', 'ADD_CHOSEN'));
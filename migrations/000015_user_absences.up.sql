@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_absences (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    handled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CHECK (ends_at > starts_at)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_user_absences_user_id ON user_absences(user_id, ends_at);
+CREATE INDEX IF NOT EXISTS idx_user_absences_unhandled ON user_absences(starts_at) WHERE handled_at IS NULL;
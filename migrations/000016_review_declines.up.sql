@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS review_declines (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('TOO_BUSY', 'NO_CONTEXT', 'CONFLICT')),
+    comment TEXT NOT NULL DEFAULT '',
+    replaced_by VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_review_declines_user_id ON review_declines(user_id);
@@ -0,0 +1,8 @@
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED', 'CLOSED'));
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
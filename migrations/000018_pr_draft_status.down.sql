@@ -0,0 +1,18 @@
+UPDATE pull_requests SET status = 'OPEN' WHERE status = 'DRAFT';
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS closed_from_draft;
+
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED', 'CLOSED'));
+
+DELETE FROM assignment_traces WHERE action = 'READY';
+
+ALTER TABLE assignment_traces
+    DROP CONSTRAINT IF EXISTS assignment_traces_action_check;
+
+ALTER TABLE assignment_traces
+    ADD CONSTRAINT assignment_traces_action_check CHECK (action IN ('CREATE', 'REASSIGN', 'TOP_UP', 'REASSIGN_CHOSEN', 'ADD_CHOSEN'));
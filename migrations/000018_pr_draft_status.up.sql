@@ -0,0 +1,14 @@
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED'));
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS closed_from_draft BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE assignment_traces
+    DROP CONSTRAINT IF EXISTS assignment_traces_action_check;
+
+ALTER TABLE assignment_traces
+    ADD CONSTRAINT assignment_traces_action_check CHECK (action IN ('CREATE', 'REASSIGN', 'TOP_UP', 'REASSIGN_CHOSEN', 'ADD_CHOSEN', 'READY'));
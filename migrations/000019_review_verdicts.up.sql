@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS review_verdicts (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    verdict VARCHAR(20) NOT NULL CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    body TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_review_verdicts_pull_request_id ON review_verdicts(pull_request_id, created_at);
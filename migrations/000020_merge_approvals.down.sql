@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS merge_overrides;
+
+ALTER TABLE team_policies
+    DROP CONSTRAINT IF EXISTS team_policies_required_approvals_check;
+
+ALTER TABLE team_policies
+    DROP COLUMN IF EXISTS required_approvals;
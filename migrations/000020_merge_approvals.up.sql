@@ -0,0 +1,21 @@
+ALTER TABLE team_policies
+    ADD COLUMN IF NOT EXISTS required_approvals INT NOT NULL DEFAULT 0 CHECK (required_approvals >= 0);
+
+UPDATE team_policies
+    SET required_approvals = LEAST(1, min_reviewers);
+
+ALTER TABLE team_policies
+    ADD CONSTRAINT team_policies_required_approvals_check CHECK (required_approvals <= min_reviewers);
+
+CREATE TABLE IF NOT EXISTS merge_overrides (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL UNIQUE,
+    user_id VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    required_approvals INT NOT NULL,
+    approved_by JSONB NOT NULL DEFAULT '[]',
+    changes_requested_by JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+    );
@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS metadata,
+    DROP COLUMN IF EXISTS description;
@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
@@ -10,8 +11,20 @@ import (
 )
 
 type Config struct {
-	MaxCountReviewers int `envconfig:"MaxCountReviewers" default:"2"`
-	Server            struct {
+	MaxCountReviewers int               `envconfig:"MaxCountReviewers" default:"2"`
+	MinCountReviewers int               `envconfig:"MinCountReviewers" default:"1"`
+	MaxOpenReviews    int               `envconfig:"MaxOpenReviews" default:"0"`
+	RequiredApprovals int               `envconfig:"RequiredApprovals" default:"1"`
+	AdminTokens       map[string]string `envconfig:"AdminTokens"`
+	Rebalance         struct {
+		Interval time.Duration `envconfig:"RebalanceInterval" default:"15m"`
+		MaxAge   time.Duration `envconfig:"RebalanceMaxAge" default:"24h"`
+		MaxSkew  int           `envconfig:"RebalanceMaxSkew" default:"2"`
+	}
+	Absence struct {
+		CheckInterval time.Duration `envconfig:"AbsenceCheckInterval" default:"1m"`
+	}
+	Server struct {
 		Port uint16 `envconfig:"HTTP_PORT" default:"8080"`
 	}
 	DB struct {
@@ -33,6 +46,11 @@ func Load(envFile string) (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("fail to load config: %e", err)
 	}
+	// Only MinCountReviewers are guaranteed, so requiring more approvals
+	// would leave PRs under the default policy unable to merge.
+	if c.RequiredApprovals < 0 || c.RequiredApprovals > c.MinCountReviewers {
+		return nil, fmt.Errorf("RequiredApprovals must be between 0 and MinCountReviewers (%d), got %d", c.MinCountReviewers, c.RequiredApprovals)
+	}
 	return c, nil
 }
 
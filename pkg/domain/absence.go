@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+type Absence struct {
+	ID        int64      `json:"id"`
+	UserID    string     `json:"user_id"`
+	StartsAt  time.Time  `json:"startsAt"`
+	EndsAt    time.Time  `json:"endsAt"`
+	Reason    string     `json:"reason,omitempty"`
+	HandledAt *time.Time `json:"handledAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,104 @@
+package domain
+
+import "time"
+
+type AssignmentAction string
+
+const (
+	AssignmentActionCreate         AssignmentAction = "CREATE"
+	AssignmentActionReassign       AssignmentAction = "REASSIGN"
+	AssignmentActionTopUp          AssignmentAction = "TOP_UP"
+	AssignmentActionReassignChosen AssignmentAction = "REASSIGN_CHOSEN"
+	AssignmentActionAddChosen      AssignmentAction = "ADD_CHOSEN"
+	AssignmentActionReady          AssignmentAction = "READY"
+)
+
+type ExclusionReason string
+
+const (
+	ExclusionAuthor          ExclusionReason = "AUTHOR"
+	ExclusionInactive        ExclusionReason = "INACTIVE"
+	ExclusionAbsent          ExclusionReason = "ABSENT"
+	ExclusionAlreadyAssigned ExclusionReason = "ALREADY_ASSIGNED"
+	ExclusionRuleViolation   ExclusionReason = "RULE_VIOLATION"
+	ExclusionOffHours        ExclusionReason = "OFF_HOURS"
+	ExclusionAtCapacity      ExclusionReason = "AT_CAPACITY"
+	ExclusionRemoved         ExclusionReason = "REMOVED"
+)
+
+type ReviewerSource string
+
+const (
+	ReviewerSourceCodeOwners ReviewerSource = "CODE_OWNERS"
+	ReviewerSourceTeam       ReviewerSource = "TEAM"
+	ReviewerSourceFallback   ReviewerSource = "FALLBACK"
+)
+
+type CandidatePool struct {
+	Source     ReviewerSource `json:"source"`
+	TeamName   string         `json:"team_name,omitempty"`
+	Candidates []string       `json:"candidates"`
+	Selected   []string       `json:"selected"`
+}
+
+type ExcludedCandidate struct {
+	UserID string          `json:"user_id"`
+	Reason ExclusionReason `json:"reason"`
+}
+
+type AssignmentTrace struct {
+	ID            int64                `json:"id"`
+	PullRequestID string               `json:"pull_request_id"`
+	Action        AssignmentAction     `json:"action"`
+	Strategy      ReviewerStrategy     `json:"strategy"`
+	Seed          int64                `json:"seed"`
+	Labels        []string             `json:"labels"`
+	Candidates    []string             `json:"candidates"`
+	Pools         []*CandidatePool     `json:"pools"`
+	Excluded      []*ExcludedCandidate `json:"excluded"`
+	Selected      []string             `json:"selected"`
+	RandomPick    string               `json:"random_pick,omitempty"`
+	SeniorPick    string               `json:"senior_pick,omitempty"`
+	Violations    []*RuleViolation     `json:"violations"`
+	// Loads is the open-review count of each candidate at assignment time.
+	Loads map[string]int `json:"loads"`
+	// Cursors is the round-robin cursor of each team before the selection.
+	Cursors   map[string]string `json:"cursors,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+type AssignmentWarningCode string
+
+const (
+	WarningUnderstaffed      AssignmentWarningCode = "UNDERSTAFFED"
+	WarningCapacityExhausted AssignmentWarningCode = "CAPACITY_EXHAUSTED"
+	WarningNoSenior          AssignmentWarningCode = "NO_SENIOR"
+	WarningRuleViolation     AssignmentWarningCode = "RULE_VIOLATION"
+	WarningFallbackReviewers AssignmentWarningCode = "FALLBACK_REVIEWERS"
+)
+
+type AssignmentWarning struct {
+	Code    AssignmentWarningCode `json:"code"`
+	Message string                `json:"message"`
+}
+
+type PreviewCandidate struct {
+	UserID   string          `json:"user_id"`
+	Source   ReviewerSource  `json:"source,omitempty"`
+	TeamName string          `json:"team_name,omitempty"`
+	Eligible bool            `json:"eligible"`
+	Reason   ExclusionReason `json:"reason,omitempty"`
+	Selected bool            `json:"selected"`
+}
+
+type AssignmentPreview struct {
+	AuthorID          string               `json:"author_id"`
+	Strategy          ReviewerStrategy     `json:"strategy"`
+	Labels            []string             `json:"labels"`
+	ProposedReviewers []string             `json:"proposed_reviewers"`
+	FallbackReviewers []string             `json:"fallback_reviewers,omitempty"`
+	UnderReviewed     bool                 `json:"under_reviewed"`
+	Candidates        []*PreviewCandidate  `json:"candidates"`
+	RuleViolations    []*RuleViolation     `json:"rule_violations"`
+	Warnings          []*AssignmentWarning `json:"warnings"`
+}
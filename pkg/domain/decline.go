@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+type DeclineReason string
+
+const (
+	DeclineTooBusy   DeclineReason = "TOO_BUSY"
+	DeclineNoContext DeclineReason = "NO_CONTEXT"
+	DeclineConflict  DeclineReason = "CONFLICT"
+)
+
+func (r DeclineReason) IsValid() bool {
+	switch r {
+	case DeclineTooBusy, DeclineNoContext, DeclineConflict:
+		return true
+	default:
+		return false
+	}
+}
+
+type ReviewDecline struct {
+	ID            int64         `json:"id"`
+	PullRequestID string        `json:"pull_request_id"`
+	UserID        string        `json:"user_id"`
+	Reason        DeclineReason `json:"reason"`
+	Comment       string        `json:"comment,omitempty"`
+	ReplacedBy    string        `json:"replaced_by,omitempty"`
+	CreatedAt     time.Time     `json:"createdAt"`
+}
+
+type ReviewerStats struct {
+	UserID           string                `json:"user_id"`
+	Username         string                `json:"username"`
+	IsActive         bool                  `json:"is_active"`
+	OpenReviews      int                   `json:"open_reviews"`
+	Declines         int                   `json:"declines"`
+	DeclinesByReason map[DeclineReason]int `json:"declines_by_reason"`
+}
+
+type TeamReviewerStats struct {
+	TeamName  string           `json:"team_name"`
+	Reviewers []*ReviewerStats `json:"reviewers"`
+}
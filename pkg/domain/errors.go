@@ -5,17 +5,35 @@ import "fmt"
 type ErrorCode string
 
 const (
-	ErrTeamExists  ErrorCode = "TEAM_EXISTS"
-	ErrPRExists    ErrorCode = "PR_EXISTS"
-	ErrPRMerged    ErrorCode = "PR_MERGED"
-	ErrNotAssigned ErrorCode = "NOT_ASSIGNED"
-	ErrNoCandidate ErrorCode = "NO_CANDIDATE"
-	ErrNotFound    ErrorCode = "NOT_FOUND"
+	ErrTeamExists        ErrorCode = "TEAM_EXISTS"
+	ErrPRExists          ErrorCode = "PR_EXISTS"
+	ErrPRMerged          ErrorCode = "PR_MERGED"
+	ErrPRClosed          ErrorCode = "PR_CLOSED"
+	ErrPRDraft           ErrorCode = "PR_DRAFT"
+	ErrNotAssigned       ErrorCode = "NOT_ASSIGNED"
+	ErrNoCandidate       ErrorCode = "NO_CANDIDATE"
+	ErrNotFound          ErrorCode = "NOT_FOUND"
+	ErrInvalidOwnership  ErrorCode = "INVALID_OWNERSHIP"
+	ErrInvalidPolicy     ErrorCode = "INVALID_POLICY"
+	ErrInvalidRule       ErrorCode = "INVALID_RULE"
+	ErrInvalidSchedule   ErrorCode = "INVALID_SCHEDULE"
+	ErrCapacityExhausted ErrorCode = "CAPACITY_EXHAUSTED"
+	ErrReviewerLimit     ErrorCode = "REVIEWER_LIMIT"
+	ErrInvalidAbsence    ErrorCode = "INVALID_ABSENCE"
+	ErrInvalidDecline    ErrorCode = "INVALID_DECLINE"
+	ErrInvalidVerdict    ErrorCode = "INVALID_VERDICT"
+	ErrInvalidOverride   ErrorCode = "INVALID_OVERRIDE"
+	ErrInvalidUpdate     ErrorCode = "INVALID_UPDATE"
+	ErrNotApproved       ErrorCode = "NOT_APPROVED"
+	ErrReviewSubmitted   ErrorCode = "REVIEW_SUBMITTED"
+	ErrForbidden         ErrorCode = "FORBIDDEN"
 )
 
 type DomainError struct {
 	Code    ErrorCode
 	Message string
+	// Details is returned to the client next to the message when set.
+	Details any
 }
 
 func (e *DomainError) Error() string {
@@ -0,0 +1,18 @@
+package domain
+
+type OwnerType string
+
+const (
+	OwnerTypeUser OwnerType = "USER"
+	OwnerTypeTeam OwnerType = "TEAM"
+)
+
+type CodeOwner struct {
+	Type OwnerType `json:"type"`
+	ID   string    `json:"id"`
+}
+
+type OwnershipRule struct {
+	Pattern string       `json:"pattern"`
+	Owners  []*CodeOwner `json:"owners"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+type WorkingHoursMode string
+
+const (
+	WorkingHoursOff    WorkingHoursMode = "OFF"
+	WorkingHoursSoft   WorkingHoursMode = "SOFT"
+	WorkingHoursStrict WorkingHoursMode = "STRICT"
+)
+
+func (m WorkingHoursMode) IsValid() bool {
+	switch m {
+	case WorkingHoursOff, WorkingHoursSoft, WorkingHoursStrict:
+		return true
+	default:
+		return false
+	}
+}
+
+type TeamPolicy struct {
+	TeamName          string           `json:"team_name"`
+	MinReviewers      int              `json:"min_reviewers"`
+	MaxReviewers      int              `json:"max_reviewers"`
+	AllowCrossTeam    bool             `json:"allow_cross_team"`
+	RequireSenior     bool             `json:"require_senior"`
+	WorkingHoursMode  WorkingHoursMode `json:"working_hours_mode"`
+	StartWithinHours  int              `json:"start_within_hours"`
+	MaxOpenReviews    int              `json:"max_open_reviews"`
+	RequiredApprovals int              `json:"required_approvals"`
+}
+
+type TeamPolicyUpdate struct {
+	MinReviewers      *int              `json:"min_reviewers"`
+	MaxReviewers      *int              `json:"max_reviewers"`
+	AllowCrossTeam    *bool             `json:"allow_cross_team"`
+	RequireSenior     *bool             `json:"require_senior"`
+	WorkingHoursMode  *WorkingHoursMode `json:"working_hours_mode"`
+	StartWithinHours  *int              `json:"start_within_hours"`
+	MaxOpenReviews    *int              `json:"max_open_reviews"`
+	RequiredApprovals *int              `json:"required_approvals"`
+}
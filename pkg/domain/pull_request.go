@@ -5,16 +5,96 @@ import "time"
 type PRStatus string
 
 const (
+	PRStatusDraft  PRStatus = "DRAFT"
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
 type PullRequest struct {
-	PullRequestID     string     `json:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            PRStatus   `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
-	CreatedAt         time.Time  `json:"createdAt"`
-	MergedAt          *time.Time `json:"mergedAt"`
+	PullRequestID     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+	Description       string            `json:"description,omitempty"`
+	AuthorID          string            `json:"author_id"`
+	Status            PRStatus          `json:"status"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	Labels            []string          `json:"labels,omitempty"`
+	AssignedReviewers []string          `json:"assigned_reviewers"`
+	Reviewers         []*ReviewerState  `json:"reviewers,omitempty"`
+	FallbackReviewers []string          `json:"fallback_reviewers,omitempty"`
+	UnderReviewed     bool              `json:"under_reviewed"`
+	RuleViolations    []*RuleViolation  `json:"rule_violations,omitempty"`
+	CreatedAt         time.Time         `json:"createdAt"`
+	MergedAt          *time.Time        `json:"mergedAt"`
+	ClosedAt          *time.Time        `json:"closedAt,omitempty"`
+}
+
+// PullRequestUpdate changes the descriptive fields of a PR. Labels replace
+// the current set; a metadata key mapped to null is removed.
+type PullRequestUpdate struct {
+	PullRequestName *string            `json:"pull_request_name"`
+	Description     *string            `json:"description"`
+	Labels          *[]string          `json:"labels"`
+	Metadata        map[string]*string `json:"metadata"`
+}
+
+type HandoffOutcome string
+
+const (
+	HandoffReassigned HandoffOutcome = "REASSIGNED"
+	HandoffRemoved    HandoffOutcome = "REMOVED"
+)
+
+type ReviewHandoff struct {
+	PullRequestID string         `json:"pull_request_id"`
+	FromUserID    string         `json:"from_user_id,omitempty"`
+	Outcome       HandoffOutcome `json:"outcome"`
+	ReplacedBy    string         `json:"replaced_by,omitempty"`
+}
+
+type ReviewerSwap struct {
+	PullRequestID string
+	FromUserID    string
+	ToUserID      string
+}
+
+// ReviewerChange edits the reviewers of one PR and is stored together with
+// the trace that explains it.
+type ReviewerChange struct {
+	PullRequestID string
+	Removed       []string
+	Added         []string
+	// UnderReviewed, when set, replaces the PR's under-reviewed flag.
+	UnderReviewed *bool
+	// Trace is optional.
+	Trace *AssignmentTrace
+	// Reassignment, when set, is recorded together with the change.
+	Reassignment *Reassignment
+	// Decline, when set, is recorded together with the change.
+	Decline *ReviewDecline
+}
+
+type PullRequestRedistribution struct {
+	PullRequestID string           `json:"pull_request_id"`
+	Handoffs      []*ReviewHandoff `json:"handoffs"`
+	UnderReviewed bool             `json:"under_reviewed"`
+}
+
+// ReviewRedistribution is what a deactivation writes in one go: the users to
+// deactivate, their reviewer swaps with the traces of the replacements and
+// the PRs whose under-reviewed flag flips. Changes are applied one by one
+// after the swaps.
+type ReviewRedistribution struct {
+	Deactivate       []string
+	Swaps            []*ReviewerSwap
+	Traces           []*AssignmentTrace
+	Changes          []*ReviewerChange
+	NowUnderReviewed []string
+	NowReviewed      []string
+}
+
+type TeamDeactivationReport struct {
+	TeamName     string                       `json:"team_name"`
+	Deactivated  []string                     `json:"deactivated"`
+	PullRequests []*PullRequestRedistribution `json:"pull_requests"`
 }
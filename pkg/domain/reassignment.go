@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+type ReassignmentReason string
+
+const (
+	ReassignmentRebalance ReassignmentReason = "REBALANCE"
+)
+
+type Reassignment struct {
+	ID            int64              `json:"id,omitempty"`
+	PullRequestID string             `json:"pull_request_id"`
+	TeamName      string             `json:"team_name"`
+	FromUserID    string             `json:"from_user_id"`
+	ToUserID      string             `json:"to_user_id"`
+	Reason        ReassignmentReason `json:"reason"`
+	CreatedAt     time.Time          `json:"createdAt"`
+}
+
+type ReviewAssignment struct {
+	PullRequestID string
+	AuthorID      string
+	UserID        string
+	AssignedAt    time.Time
+}
+
+type TeamLoadSkew struct {
+	TeamName   string `json:"team_name"`
+	SkewBefore int    `json:"skew_before"`
+	SkewAfter  int    `json:"skew_after"`
+}
+
+type RebalanceReport struct {
+	DryRun      bool            `json:"dry_run"`
+	Teams       []*TeamLoadSkew `json:"teams"`
+	Moves       []*Reassignment `json:"moves"`
+	GeneratedAt time.Time       `json:"generatedAt"`
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+type ReviewVerdict string
+
+const (
+	VerdictApproved         ReviewVerdict = "APPROVED"
+	VerdictChangesRequested ReviewVerdict = "CHANGES_REQUESTED"
+	VerdictCommented        ReviewVerdict = "COMMENTED"
+)
+
+func (v ReviewVerdict) IsValid() bool {
+	switch v {
+	case VerdictApproved, VerdictChangesRequested, VerdictCommented:
+		return true
+	default:
+		return false
+	}
+}
+
+type Review struct {
+	ID            int64         `json:"id"`
+	PullRequestID string        `json:"pull_request_id"`
+	UserID        string        `json:"user_id"`
+	Verdict       ReviewVerdict `json:"verdict"`
+	Body          string        `json:"body,omitempty"`
+	CreatedAt     time.Time     `json:"createdAt"`
+}
+
+// ReviewerStatePending marks an assigned reviewer who has not submitted a
+// verdict yet.
+const ReviewerStatePending = "PENDING"
+
+// ReviewerState is an assigned reviewer with their latest verdict, or
+// PENDING when they have none.
+type ReviewerState struct {
+	UserID     string     `json:"user_id"`
+	State      string     `json:"state"`
+	ReviewedAt *time.Time `json:"reviewedAt,omitempty"`
+}
+
+// ApprovalStatus compares the verdicts of a PR's assigned reviewers with
+// the approvals its team requires before merging.
+type ApprovalStatus struct {
+	RequiredApprovals  int      `json:"required_approvals"`
+	ApprovedBy         []string `json:"approved_by"`
+	MissingApprovals   int      `json:"missing_approvals"`
+	PendingReviewers   []string `json:"pending_reviewers"`
+	ChangesRequestedBy []string `json:"changes_requested_by"`
+}
+
+func (s *ApprovalStatus) Satisfied() bool {
+	return s.MissingApprovals == 0 && len(s.ChangesRequestedBy) == 0
+}
+
+// MergeOverride records an admin merging a PR that did not pass the
+// approval gate, together with the approvals it had at that moment.
+type MergeOverride struct {
+	ID                 int64     `json:"id"`
+	PullRequestID      string    `json:"pull_request_id"`
+	UserID             string    `json:"user_id"`
+	Reason             string    `json:"reason"`
+	RequiredApprovals  int       `json:"required_approvals"`
+	ApprovedBy         []string  `json:"approved_by"`
+	ChangesRequestedBy []string  `json:"changes_requested_by"`
+	CreatedAt          time.Time `json:"createdAt"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+type RuleType string
+
+const (
+	RuleAvoid    RuleType = "AVOID"
+	RulePrefer   RuleType = "PREFER"
+	RuleNoRepeat RuleType = "NO_REPEAT"
+)
+
+func (t RuleType) IsValid() bool {
+	switch t {
+	case RuleAvoid, RulePrefer, RuleNoRepeat:
+		return true
+	default:
+		return false
+	}
+}
+
+type ReviewerRule struct {
+	ID         int64    `json:"id"`
+	Type       RuleType `json:"type"`
+	AuthorID   string   `json:"author_id,omitempty"`
+	ReviewerID string   `json:"reviewer_id,omitempty"`
+	// AuthorLevel and ReviewerLevel make a PREFER rule match by level
+	// instead of by user; an empty AuthorLevel matches every author.
+	AuthorLevel   UserLevel `json:"author_level,omitempty"`
+	ReviewerLevel UserLevel `json:"reviewer_level,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type RuleViolation struct {
+	RuleID     int64    `json:"rule_id"`
+	Type       RuleType `json:"type"`
+	ReviewerID string   `json:"reviewer_id"`
+}
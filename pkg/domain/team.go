@@ -1,12 +1,38 @@
 package domain
 
+type ReviewerStrategy string
+
+const (
+	StrategyRandom      ReviewerStrategy = "RANDOM"
+	StrategyRoundRobin  ReviewerStrategy = "ROUND_ROBIN"
+	StrategyLeastLoaded ReviewerStrategy = "LEAST_LOADED"
+	StrategyWeighted    ReviewerStrategy = "WEIGHTED"
+)
+
+func (s ReviewerStrategy) IsValid() bool {
+	switch s {
+	case StrategyRandom, StrategyRoundRobin, StrategyLeastLoaded, StrategyWeighted:
+		return true
+	default:
+		return false
+	}
+}
+
 type Team struct {
-	TeamName string        `json:"team_name"`
-	Members  []*TeamMember `json:"members"`
+	TeamName         string           `json:"team_name"`
+	ReviewerStrategy ReviewerStrategy `json:"reviewer_strategy,omitempty"`
+	FallbackTeams    []string         `json:"fallback_teams,omitempty"`
+	Members          []*TeamMember    `json:"members"`
 }
 
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID         string    `json:"user_id"`
+	Username       string    `json:"username"`
+	IsActive       bool      `json:"is_active"`
+	Level          UserLevel `json:"level,omitempty"`
+	Timezone       string    `json:"timezone,omitempty"`
+	WorkStart      string    `json:"work_start,omitempty"`
+	WorkEnd        string    `json:"work_end,omitempty"`
+	MaxOpenReviews *int      `json:"max_open_reviews,omitempty"`
+	Skills         []string  `json:"skills,omitempty"`
 }
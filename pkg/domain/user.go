@@ -1,14 +1,47 @@
 package domain
 
+type UserLevel string
+
+const (
+	LevelJunior UserLevel = "JUNIOR"
+	LevelMiddle UserLevel = "MIDDLE"
+	LevelSenior UserLevel = "SENIOR"
+	LevelLead   UserLevel = "LEAD"
+)
+
+func (l UserLevel) IsValid() bool {
+	switch l {
+	case LevelJunior, LevelMiddle, LevelSenior, LevelLead:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l UserLevel) IsSenior() bool {
+	return l == LevelSenior || l == LevelLead
+}
+
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID         string    `json:"user_id"`
+	Username       string    `json:"username"`
+	TeamName       string    `json:"team_name"`
+	IsActive       bool      `json:"is_active"`
+	Level          UserLevel `json:"level,omitempty"`
+	Timezone       string    `json:"timezone,omitempty"`
+	WorkStart      string    `json:"work_start,omitempty"`
+	WorkEnd        string    `json:"work_end,omitempty"`
+	MaxOpenReviews *int      `json:"max_open_reviews,omitempty"`
+	Skills         []string  `json:"skills,omitempty"`
 }
 
 type UserUpdate struct {
-	Username *string `json:"username"`
-	TeamName *string `json:"team_name"`
-	IsActive *bool   `json:"is_active"`
+	Username       *string    `json:"username"`
+	TeamName       *string    `json:"team_name"`
+	IsActive       *bool      `json:"is_active"`
+	Level          *UserLevel `json:"level"`
+	Timezone       *string    `json:"timezone"`
+	WorkStart      *string    `json:"work_start"`
+	WorkEnd        *string    `json:"work_end"`
+	MaxOpenReviews *int       `json:"max_open_reviews"`
 }
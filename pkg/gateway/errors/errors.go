@@ -15,6 +15,7 @@ type ErrorResponse struct {
 type ErrorDetail struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
 }
 
 func RespondError(c *gin.Context, statusCode int, code, message string) {
@@ -35,7 +36,13 @@ func HandleDomainError(c *gin.Context, err error) {
 	}
 
 	statusCode := getHTTPStatusCode(domainErr.Code)
-	RespondError(c, statusCode, string(domainErr.Code), domainErr.Message)
+	c.JSON(statusCode, ErrorResponse{
+		Error: ErrorDetail{
+			Code:    string(domainErr.Code),
+			Message: domainErr.Message,
+			Details: domainErr.Details,
+		},
+	})
 }
 
 func getHTTPStatusCode(code domain.ErrorCode) int {
@@ -44,7 +51,11 @@ func getHTTPStatusCode(code domain.ErrorCode) int {
 		return http.StatusNotFound
 	case domain.ErrTeamExists, domain.ErrPRExists:
 		return http.StatusBadRequest
-	case domain.ErrPRMerged, domain.ErrNotAssigned, domain.ErrNoCandidate:
+	case domain.ErrInvalidOwnership, domain.ErrInvalidPolicy, domain.ErrInvalidRule, domain.ErrInvalidSchedule, domain.ErrInvalidAbsence, domain.ErrInvalidDecline, domain.ErrInvalidVerdict, domain.ErrInvalidOverride, domain.ErrInvalidUpdate:
+		return http.StatusBadRequest
+	case domain.ErrForbidden:
+		return http.StatusForbidden
+	case domain.ErrPRMerged, domain.ErrPRClosed, domain.ErrPRDraft, domain.ErrNotAssigned, domain.ErrNoCandidate, domain.ErrCapacityExhausted, domain.ErrReviewerLimit, domain.ErrNotApproved, domain.ErrReviewSubmitted:
 		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
@@ -0,0 +1,21 @@
+package ownership
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GetOwnershipHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rules, err := cases.Ownership.GetRules(c.Request.Context())
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"rules": rules})
+	}
+}
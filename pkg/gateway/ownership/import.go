@@ -0,0 +1,31 @@
+package ownership
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImportCodeOwnersRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+func ImportCodeOwnersHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ImportCodeOwnersRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		rules, err := cases.Ownership.ImportCodeOwners(c.Request.Context(), req.Content)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"rules": rules})
+	}
+}
@@ -0,0 +1,32 @@
+package ownership
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SetOwnershipRequest struct {
+	Rules []*domain.OwnershipRule `json:"rules" binding:"required"`
+}
+
+func SetOwnershipHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req SetOwnershipRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		rules, err := cases.Ownership.SetRules(c.Request.Context(), req.Rules)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"rules": rules})
+	}
+}
@@ -0,0 +1,32 @@
+package pullrequest
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AddReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	UserID        string `json:"user_id" binding:"required"`
+}
+
+func AddReviewerHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req AddReviewerRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		pr, err := cases.PullRequest.AddReviewer(c.Request.Context(), req.PullRequestID, req.UserID)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"pr": pr})
+	}
+}
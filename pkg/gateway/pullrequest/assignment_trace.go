@@ -0,0 +1,30 @@
+package pullrequest
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GetAssignmentTraceHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		prID := c.Query("pull_request_id")
+		if prID == "" {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id query parameter is required")
+			return
+		}
+
+		traces, err := cases.PullRequest.GetAssignmentTraces(c.Request.Context(), prID)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"pull_request_id": prID,
+			"traces":          traces,
+		})
+	}
+}
@@ -9,9 +9,13 @@ import (
 )
 
 type CreatePullRequestRequest struct {
-	PullRequestID   string `json:"pull_request_id" binding:"required"`
-	PullRequestName string `json:"pull_request_name" binding:"required"`
-	AuthorID        string `json:"author_id" binding:"required"`
+	PullRequestID   string   `json:"pull_request_id" binding:"required"`
+	PullRequestName string   `json:"pull_request_name" binding:"required"`
+	AuthorID        string   `json:"author_id" binding:"required"`
+	ChangedFiles    []string `json:"changed_files"`
+	Labels          []string `json:"labels"`
+	QueueIfFull     bool     `json:"queue_if_full"`
+	Draft           bool     `json:"draft"`
 }
 
 func CreatePullRequestHandler(cases *usecase.Cases) gin.HandlerFunc {
@@ -22,7 +26,15 @@ func CreatePullRequestHandler(cases *usecase.Cases) gin.HandlerFunc {
 			return
 		}
 
-		pr, err := cases.PullRequest.CreatePullRequest(c.Request.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+		pr, err := cases.PullRequest.CreatePullRequest(c.Request.Context(), &usecase.CreatePullRequestParams{
+			PullRequestID:   req.PullRequestID,
+			PullRequestName: req.PullRequestName,
+			AuthorID:        req.AuthorID,
+			ChangedFiles:    req.ChangedFiles,
+			Labels:          req.Labels,
+			QueueIfFull:     req.QueueIfFull,
+			Draft:           req.Draft,
+		})
 		if err != nil {
 			errors.HandleDomainError(c, err)
 			return
@@ -0,0 +1,38 @@
+package pullrequest
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeclineReviewRequest struct {
+	PullRequestID string               `json:"pull_request_id" binding:"required"`
+	UserID        string               `json:"user_id" binding:"required"`
+	Reason        domain.DeclineReason `json:"reason" binding:"required"`
+	Comment       string               `json:"comment"`
+}
+
+func DeclineReviewHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req DeclineReviewRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		pr, decline, err := cases.PullRequest.DeclineReview(c.Request.Context(), req.PullRequestID, req.UserID, req.Reason, req.Comment)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"pr":      pr,
+			"decline": decline,
+		})
+	}
+}
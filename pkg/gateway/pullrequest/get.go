@@ -0,0 +1,31 @@
+package pullrequest
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GetPullRequestHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		prID := c.Query("pull_request_id")
+		if prID == "" {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "pull_request_id query parameter is required")
+			return
+		}
+
+		pr, override, err := cases.PullRequest.GetPullRequest(c.Request.Context(), prID)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		response := gin.H{"pr": pr}
+		if override != nil {
+			response["override"] = override
+		}
+		c.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,37 @@
+package pullrequest
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MarkReadyRequest struct {
+	PullRequestID string   `json:"pull_request_id" binding:"required"`
+	ChangedFiles  []string `json:"changed_files"`
+	QueueIfFull   bool     `json:"queue_if_full"`
+}
+
+func MarkReadyHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req MarkReadyRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		pr, err := cases.PullRequest.MarkReady(c.Request.Context(), &usecase.MarkReadyParams{
+			PullRequestID: req.PullRequestID,
+			ChangedFiles:  req.ChangedFiles,
+			QueueIfFull:   req.QueueIfFull,
+		})
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"pr": pr})
+	}
+}
@@ -9,7 +9,9 @@ import (
 )
 
 type MergePullRequestRequest struct {
-	PullRequestID string `json:"pull_request_id" binding:"required"`
+	PullRequestID  string `json:"pull_request_id" binding:"required"`
+	Force          bool   `json:"force"`
+	OverrideReason string `json:"override_reason"`
 }
 
 func MergePullRequestHandler(cases *usecase.Cases) gin.HandlerFunc {
@@ -20,12 +22,21 @@ func MergePullRequestHandler(cases *usecase.Cases) gin.HandlerFunc {
 			return
 		}
 
-		pr, err := cases.PullRequest.MergePullRequest(c.Request.Context(), req.PullRequestID)
+		pr, override, err := cases.PullRequest.MergePullRequest(c.Request.Context(), &usecase.MergePullRequestParams{
+			PullRequestID:  req.PullRequestID,
+			Force:          req.Force,
+			AdminToken:     c.GetHeader("X-Admin-Token"),
+			OverrideReason: req.OverrideReason,
+		})
 		if err != nil {
 			errors.HandleDomainError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"pr": pr})
+		response := gin.H{"pr": pr}
+		if override != nil {
+			response["override"] = override
+		}
+		c.JSON(http.StatusOK, response)
 	}
 }
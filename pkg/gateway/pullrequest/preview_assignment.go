@@ -0,0 +1,39 @@
+package pullrequest
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PreviewAssignmentRequest struct {
+	PullRequestID string   `json:"pull_request_id"`
+	AuthorID      string   `json:"author_id" binding:"required"`
+	ChangedFiles  []string `json:"changed_files"`
+	Labels        []string `json:"labels"`
+}
+
+func PreviewAssignmentHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req PreviewAssignmentRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		preview, err := cases.PullRequest.PreviewAssignment(c.Request.Context(), &usecase.CreatePullRequestParams{
+			PullRequestID: req.PullRequestID,
+			AuthorID:      req.AuthorID,
+			ChangedFiles:  req.ChangedFiles,
+			Labels:        req.Labels,
+		})
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"preview": preview})
+	}
+}
@@ -11,6 +11,7 @@ import (
 type ReassignPullRequestRequest struct {
 	PullRequestID string `json:"pull_request_id" binding:"required"`
 	OldUserID     string `json:"old_reviewer_id" binding:"required"`
+	NewUserID     string `json:"new_reviewer_id"`
 }
 
 func ReassignPullRequestHandler(cases *usecase.Cases) gin.HandlerFunc {
@@ -21,7 +22,7 @@ func ReassignPullRequestHandler(cases *usecase.Cases) gin.HandlerFunc {
 			return
 		}
 
-		pr, replacedBy, err := cases.PullRequest.ReassignReviewer(c.Request.Context(), req.PullRequestID, req.OldUserID)
+		pr, replacedBy, err := cases.PullRequest.ReassignReviewer(c.Request.Context(), req.PullRequestID, req.OldUserID, req.NewUserID)
 		if err != nil {
 			errors.HandleDomainError(c, err)
 			return
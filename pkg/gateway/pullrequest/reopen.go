@@ -0,0 +1,34 @@
+package pullrequest
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReopenPullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+}
+
+func ReopenPullRequestHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ReopenPullRequestRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		pr, handoffs, err := cases.PullRequest.ReopenPullRequest(c.Request.Context(), req.PullRequestID)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"pr":                 pr,
+			"replaced_reviewers": handoffs,
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package pullrequest
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SubmitReviewRequest struct {
+	PullRequestID string               `json:"pull_request_id" binding:"required"`
+	UserID        string               `json:"user_id" binding:"required"`
+	Verdict       domain.ReviewVerdict `json:"verdict" binding:"required"`
+	Body          string               `json:"body"`
+}
+
+func SubmitReviewHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req SubmitReviewRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		pr, review, err := cases.PullRequest.SubmitReview(c.Request.Context(), req.PullRequestID, req.UserID, req.Verdict, req.Body)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"pr":     pr,
+			"review": review,
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package pullrequest
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdatePullRequestRequest struct {
+	PullRequestID string `json:"pull_request_id" binding:"required"`
+	domain.PullRequestUpdate
+}
+
+func UpdatePullRequestHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req UpdatePullRequestRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		pr, err := cases.PullRequest.UpdatePullRequest(c.Request.Context(), req.PullRequestID, &req.PullRequestUpdate)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"pr": pr})
+	}
+}
@@ -0,0 +1,34 @@
+package rebalance
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultHistoryLimit = 50
+
+func GetHistoryHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := defaultHistoryLimit
+		if raw := c.Query("limit"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed <= 0 {
+				errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "limit must be a positive integer")
+				return
+			}
+			limit = parsed
+		}
+
+		reassignments, err := cases.Rebalancer.ListReassignments(c.Request.Context(), limit)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"reassignments": reassignments})
+	}
+}
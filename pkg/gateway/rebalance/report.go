@@ -0,0 +1,21 @@
+package rebalance
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GetReportHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, err := cases.Rebalancer.Rebalance(c.Request.Context(), c.Query("team_name"), true)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
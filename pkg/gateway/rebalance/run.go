@@ -0,0 +1,33 @@
+package rebalance
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RunRequest struct {
+	TeamName string `json:"team_name"`
+}
+
+func RunHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req RunRequest
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+				return
+			}
+		}
+
+		report, err := cases.Rebalancer.Rebalance(c.Request.Context(), req.TeamName, false)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
@@ -2,7 +2,11 @@ package gateway
 
 import (
 	"Avito/pkg/config"
+	"Avito/pkg/gateway/ownership"
 	"Avito/pkg/gateway/pullrequest"
+	"Avito/pkg/gateway/rebalance"
+	"Avito/pkg/gateway/rule"
+	"Avito/pkg/gateway/stats"
 	"Avito/pkg/gateway/team"
 	"Avito/pkg/gateway/user"
 	"Avito/pkg/usecase"
@@ -20,18 +24,62 @@ func setupRouter(ctx context.Context, cfg *config.Config, r *gin.Engine, cases *
 	{
 		teamGroup.POST("/add", team.CreateTeamHandler(cases))
 		teamGroup.GET("/get", team.GetTeamHandler(cases))
+		teamGroup.POST("/policy/set", team.SetPolicyHandler(cases))
+		teamGroup.GET("/policy/get", team.GetPolicyHandler(cases))
+		teamGroup.POST("/deactivateMembers", team.DeactivateMembersHandler(cases))
 	}
 
 	userGroup := r.Group("/users")
 	{
 		userGroup.POST("/setIsActive", user.SetIsActiveHandler(cases))
 		userGroup.GET("/getReview", user.GetUserReviewsHandler(cases))
+		userGroup.POST("/absence/add", user.AddAbsenceHandler(cases))
+		userGroup.POST("/absence/delete", user.DeleteAbsenceHandler(cases))
+		userGroup.GET("/absence/list", user.ListAbsencesHandler(cases))
 	}
 
 	prGroup := r.Group("/pullRequest")
 	{
 		prGroup.POST("/create", pullrequest.CreatePullRequestHandler(cases))
+		prGroup.GET("/get", pullrequest.GetPullRequestHandler(cases))
+		prGroup.POST("/update", pullrequest.UpdatePullRequestHandler(cases))
 		prGroup.POST("/merge", pullrequest.MergePullRequestHandler(cases))
+		prGroup.POST("/close", pullrequest.ClosePullRequestHandler(cases))
+		prGroup.POST("/reopen", pullrequest.ReopenPullRequestHandler(cases))
+		prGroup.POST("/markReady", pullrequest.MarkReadyHandler(cases))
 		prGroup.POST("/reassign", pullrequest.ReassignPullRequestHandler(cases))
+		prGroup.POST("/addReviewer", pullrequest.AddReviewerHandler(cases))
+		prGroup.POST("/removeReviewer", pullrequest.RemoveReviewerHandler(cases))
+		prGroup.POST("/decline", pullrequest.DeclineReviewHandler(cases))
+		prGroup.POST("/previewAssignment", pullrequest.PreviewAssignmentHandler(cases))
+		prGroup.POST("/submitReview", pullrequest.SubmitReviewHandler(cases))
+		prGroup.GET("/reviews", pullrequest.GetReviewsHandler(cases))
+		prGroup.GET("/assignmentTrace", pullrequest.GetAssignmentTraceHandler(cases))
+	}
+
+	ownershipGroup := r.Group("/ownership")
+	{
+		ownershipGroup.POST("/set", ownership.SetOwnershipHandler(cases))
+		ownershipGroup.POST("/import", ownership.ImportCodeOwnersHandler(cases))
+		ownershipGroup.GET("/get", ownership.GetOwnershipHandler(cases))
+	}
+
+	ruleGroup := r.Group("/rules")
+	{
+		ruleGroup.POST("/add", rule.AddRuleHandler(cases))
+		ruleGroup.POST("/delete", rule.DeleteRuleHandler(cases))
+		ruleGroup.GET("/list", rule.ListRulesHandler(cases))
+	}
+
+	rebalanceGroup := r.Group("/rebalance")
+	{
+		rebalanceGroup.GET("/report", rebalance.GetReportHandler(cases))
+		rebalanceGroup.POST("/run", rebalance.RunHandler(cases))
+		rebalanceGroup.GET("/history", rebalance.GetHistoryHandler(cases))
+	}
+
+	statsGroup := r.Group("/stats")
+	{
+		statsGroup.GET("/reviewers", stats.GetReviewerStatsHandler(cases))
 	}
 }
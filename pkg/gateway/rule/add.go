@@ -0,0 +1,42 @@
+package rule
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AddRuleRequest struct {
+	Type          domain.RuleType  `json:"type" binding:"required"`
+	AuthorID      string           `json:"author_id"`
+	ReviewerID    string           `json:"reviewer_id"`
+	AuthorLevel   domain.UserLevel `json:"author_level"`
+	ReviewerLevel domain.UserLevel `json:"reviewer_level"`
+}
+
+func AddRuleHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req AddRuleRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		rule, err := cases.Rules.CreateRule(c.Request.Context(), &domain.ReviewerRule{
+			Type:          req.Type,
+			AuthorID:      req.AuthorID,
+			ReviewerID:    req.ReviewerID,
+			AuthorLevel:   req.AuthorLevel,
+			ReviewerLevel: req.ReviewerLevel,
+		})
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"rule": rule})
+	}
+}
@@ -0,0 +1,30 @@
+package rule
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeleteRuleRequest struct {
+	RuleID int64 `json:"rule_id" binding:"required"`
+}
+
+func DeleteRuleHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req DeleteRuleRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		if err := cases.Rules.DeleteRule(c.Request.Context(), req.RuleID); err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"rule_id": req.RuleID})
+	}
+}
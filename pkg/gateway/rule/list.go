@@ -0,0 +1,21 @@
+package rule
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ListRulesHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rules, err := cases.Rules.ListRules(c.Request.Context())
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"rules": rules})
+	}
+}
@@ -16,6 +16,28 @@ func CreateTeamHandler(cases *usecase.Cases) gin.HandlerFunc {
 			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
 			return
 		}
+		if req.ReviewerStrategy != "" && !req.ReviewerStrategy.IsValid() {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "unknown reviewer_strategy")
+			return
+		}
+		for _, member := range req.Members {
+			if member.Level != "" && !member.Level.IsValid() {
+				errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "unknown member level")
+				return
+			}
+			if member.MaxOpenReviews != nil && *member.MaxOpenReviews < 0 {
+				errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "max_open_reviews must not be negative")
+				return
+			}
+		}
+		seenFallbacks := make(map[string]bool, len(req.FallbackTeams))
+		for _, fallbackTeam := range req.FallbackTeams {
+			if fallbackTeam == req.TeamName || seenFallbacks[fallbackTeam] {
+				errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "fallback_teams must be unique and differ from team_name")
+				return
+			}
+			seenFallbacks[fallbackTeam] = true
+		}
 
 		team, err := cases.Team.CreateTeam(c.Request.Context(), req)
 		if err != nil {
@@ -0,0 +1,32 @@
+package team
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeactivateMembersRequest struct {
+	TeamName string   `json:"team_name" binding:"required"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+func DeactivateMembersHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req DeactivateMembersRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		report, err := cases.Team.DeactivateMembers(c.Request.Context(), req.TeamName, req.UserIDs)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
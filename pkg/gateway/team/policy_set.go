@@ -0,0 +1,33 @@
+package team
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SetPolicyRequest struct {
+	TeamName string `json:"team_name" binding:"required"`
+	domain.TeamPolicyUpdate
+}
+
+func SetPolicyHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req SetPolicyRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		policy, err := cases.Team.SetPolicy(c.Request.Context(), req.TeamName, &req.TeamPolicyUpdate)
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"policy": policy})
+	}
+}
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AddAbsenceRequest struct {
+	UserID   string    `json:"user_id" binding:"required"`
+	StartsAt time.Time `json:"startsAt" binding:"required"`
+	EndsAt   time.Time `json:"endsAt" binding:"required"`
+	Reason   string    `json:"reason"`
+}
+
+func AddAbsenceHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req AddAbsenceRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		absence, err := cases.Absences.AddAbsence(c.Request.Context(), &domain.Absence{
+			UserID:   req.UserID,
+			StartsAt: req.StartsAt,
+			EndsAt:   req.EndsAt,
+			Reason:   req.Reason,
+		})
+		if err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"absence": absence})
+	}
+}
@@ -0,0 +1,30 @@
+package user
+
+import (
+	"Avito/pkg/gateway/errors"
+	"Avito/pkg/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeleteAbsenceRequest struct {
+	AbsenceID int64 `json:"absence_id" binding:"required"`
+}
+
+func DeleteAbsenceHandler(cases *usecase.Cases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req DeleteAbsenceRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+			return
+		}
+
+		if err := cases.Absences.DeleteAbsence(c.Request.Context(), req.AbsenceID); err != nil {
+			errors.HandleDomainError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"absence_id": req.AbsenceID})
+	}
+}
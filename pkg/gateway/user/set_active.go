@@ -9,8 +9,9 @@ import (
 )
 
 type SetIsActiveRequest struct {
-	UserID   string `json:"user_id" binding:"required"`
-	IsActive bool   `json:"is_active"`
+	UserID              string `json:"user_id" binding:"required"`
+	IsActive            bool   `json:"is_active"`
+	ReassignOpenReviews bool   `json:"reassign_open_reviews"`
 }
 
 func SetIsActiveHandler(cases *usecase.Cases) gin.HandlerFunc {
@@ -20,12 +21,16 @@ func SetIsActiveHandler(cases *usecase.Cases) gin.HandlerFunc {
 			errors.RespondError(c, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
 			return
 		}
-		user, err := cases.User.SetIsActive(c.Request.Context(), req.UserID, req.IsActive)
+		user, handoffs, err := cases.User.SetIsActive(c.Request.Context(), req.UserID, req.IsActive, req.ReassignOpenReviews)
 		if err != nil {
 			errors.HandleDomainError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"user": user})
+		response := gin.H{"user": user}
+		if req.ReassignOpenReviews && !req.IsActive {
+			response["affected_pull_requests"] = handoffs
+		}
+		c.JSON(http.StatusOK, response)
 	}
 }
@@ -0,0 +1,131 @@
+package pg
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Absence struct {
+	psql sq.StatementBuilderType
+	pool *pgxpool.Pool
+}
+
+func NewAbsence(pool *pgxpool.Pool) *Absence {
+	return &Absence{
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		pool: pool,
+	}
+}
+
+func (a *Absence) Create(ctx context.Context, absence *domain.Absence) error {
+	q := a.psql.Insert("user_absences").
+		Columns("user_id", "starts_at", "ends_at", "reason", "created_at").
+		Values(absence.UserID, absence.StartsAt, absence.EndsAt, absence.Reason, absence.CreatedAt).
+		Suffix("RETURNING id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	err = a.pool.QueryRow(ctx, sql, args...).Scan(&absence.ID)
+	if err != nil {
+		return fmt.Errorf("error creating absence: %w", err)
+	}
+
+	return nil
+}
+
+func (a *Absence) Delete(ctx context.Context, absenceID int64) error {
+	q := a.psql.Delete("user_absences").
+		Where(sq.Eq{"id": absenceID})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	result, err := a.pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("error deleting absence: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return &domain.DomainError{Code: domain.ErrNotFound, Message: "absence not found"}
+	}
+
+	return nil
+}
+
+func (a *Absence) GetByUserID(ctx context.Context, userID string) ([]*domain.Absence, error) {
+	return a.query(ctx, a.selectAbsences().Where(sq.Eq{"user_id": userID}))
+}
+
+// GetStartedUnhandled returns absences that cover at and whose open reviews
+// have not been handed off yet.
+func (a *Absence) GetStartedUnhandled(ctx context.Context, at time.Time) ([]*domain.Absence, error) {
+	return a.query(ctx, a.selectAbsences().Where(sq.And{
+		sq.Eq{"handled_at": nil},
+		sq.LtOrEq{"starts_at": at},
+		sq.Gt{"ends_at": at},
+	}))
+}
+
+func (a *Absence) MarkHandled(ctx context.Context, absenceID int64, at time.Time) error {
+	q := a.psql.Update("user_absences").
+		Set("handled_at", at).
+		Where(sq.Eq{"id": absenceID})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	if _, err := a.pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("error marking absence handled: %w", err)
+	}
+
+	return nil
+}
+
+func (a *Absence) selectAbsences() sq.SelectBuilder {
+	return a.psql.Select("id", "user_id", "starts_at", "ends_at", "reason", "handled_at", "created_at").
+		From("user_absences").
+		OrderBy("starts_at", "id")
+}
+
+func (a *Absence) query(ctx context.Context, q sq.SelectBuilder) ([]*domain.Absence, error) {
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := a.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying absences: %w", err)
+	}
+	defer rows.Close()
+
+	absences := []*domain.Absence{}
+	for rows.Next() {
+		var absence domain.Absence
+		if err := rows.Scan(
+			&absence.ID, &absence.UserID, &absence.StartsAt, &absence.EndsAt,
+			&absence.Reason, &absence.HandledAt, &absence.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning absence: %w", err)
+		}
+		absences = append(absences, &absence)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating absences: %w", err)
+	}
+
+	return absences, nil
+}
@@ -0,0 +1,147 @@
+package pg
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// queryRower is satisfied by both the pool and a transaction, so traces can
+// be written together with the reviewer change they describe.
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type AssignmentTrace struct {
+	psql sq.StatementBuilderType
+	pool *pgxpool.Pool
+}
+
+func NewAssignmentTrace(pool *pgxpool.Pool) *AssignmentTrace {
+	return &AssignmentTrace{
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		pool: pool,
+	}
+}
+
+func (a *AssignmentTrace) Create(ctx context.Context, trace *domain.AssignmentTrace) error {
+	return insertAssignmentTrace(ctx, a.pool, a.psql, trace)
+}
+
+func insertAssignmentTrace(ctx context.Context, db queryRower, psql sq.StatementBuilderType, trace *domain.AssignmentTrace) error {
+	candidates, err := json.Marshal(trace.Candidates)
+	if err != nil {
+		return fmt.Errorf("error encoding candidates: %w", err)
+	}
+	pools, err := json.Marshal(trace.Pools)
+	if err != nil {
+		return fmt.Errorf("error encoding candidate pools: %w", err)
+	}
+	excluded, err := json.Marshal(trace.Excluded)
+	if err != nil {
+		return fmt.Errorf("error encoding exclusions: %w", err)
+	}
+	selected, err := json.Marshal(trace.Selected)
+	if err != nil {
+		return fmt.Errorf("error encoding selected reviewers: %w", err)
+	}
+	violations, err := json.Marshal(trace.Violations)
+	if err != nil {
+		return fmt.Errorf("error encoding rule violations: %w", err)
+	}
+	labels, err := json.Marshal(trace.Labels)
+	if err != nil {
+		return fmt.Errorf("error encoding labels: %w", err)
+	}
+	loads, err := json.Marshal(trace.Loads)
+	if err != nil {
+		return fmt.Errorf("error encoding loads: %w", err)
+	}
+	cursors, err := json.Marshal(trace.Cursors)
+	if err != nil {
+		return fmt.Errorf("error encoding cursors: %w", err)
+	}
+
+	q := psql.Insert("assignment_traces").
+		Columns("pull_request_id", "action", "strategy", "seed", "labels", "candidates", "pools", "excluded", "selected", "random_pick", "senior_pick", "violations", "loads", "cursors", "created_at").
+		Values(trace.PullRequestID, trace.Action, trace.Strategy, trace.Seed, labels, candidates, pools, excluded, selected, trace.RandomPick, trace.SeniorPick, violations, loads, cursors, trace.CreatedAt).
+		Suffix("RETURNING id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	err = db.QueryRow(ctx, sql, args...).Scan(&trace.ID)
+	if err != nil {
+		return fmt.Errorf("error creating assignment trace: %w", err)
+	}
+
+	return nil
+}
+
+func (a *AssignmentTrace) GetByPullRequestID(ctx context.Context, prID string) ([]*domain.AssignmentTrace, error) {
+	q := a.psql.Select("id", "pull_request_id", "action", "strategy", "seed", "labels", "candidates", "pools", "excluded", "selected", "random_pick", "senior_pick", "violations", "loads", "cursors", "created_at").
+		From("assignment_traces").
+		Where(sq.Eq{"pull_request_id": prID}).
+		OrderBy("id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := a.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying assignment traces: %w", err)
+	}
+	defer rows.Close()
+
+	traces := []*domain.AssignmentTrace{}
+	for rows.Next() {
+		var trace domain.AssignmentTrace
+		var labels, candidates, pools, excluded, selected, violations, loads, cursors []byte
+		if err := rows.Scan(
+			&trace.ID, &trace.PullRequestID, &trace.Action, &trace.Strategy, &trace.Seed,
+			&labels, &candidates, &pools, &excluded, &selected, &trace.RandomPick, &trace.SeniorPick, &violations, &loads, &cursors, &trace.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning assignment trace: %w", err)
+		}
+		if err := json.Unmarshal(labels, &trace.Labels); err != nil {
+			return nil, fmt.Errorf("error decoding labels: %w", err)
+		}
+		if err := json.Unmarshal(candidates, &trace.Candidates); err != nil {
+			return nil, fmt.Errorf("error decoding candidates: %w", err)
+		}
+		if err := json.Unmarshal(pools, &trace.Pools); err != nil {
+			return nil, fmt.Errorf("error decoding candidate pools: %w", err)
+		}
+		if err := json.Unmarshal(excluded, &trace.Excluded); err != nil {
+			return nil, fmt.Errorf("error decoding exclusions: %w", err)
+		}
+		if err := json.Unmarshal(selected, &trace.Selected); err != nil {
+			return nil, fmt.Errorf("error decoding selected reviewers: %w", err)
+		}
+		if err := json.Unmarshal(violations, &trace.Violations); err != nil {
+			return nil, fmt.Errorf("error decoding rule violations: %w", err)
+		}
+		if err := json.Unmarshal(loads, &trace.Loads); err != nil {
+			return nil, fmt.Errorf("error decoding loads: %w", err)
+		}
+		if err := json.Unmarshal(cursors, &trace.Cursors); err != nil {
+			return nil, fmt.Errorf("error decoding cursors: %w", err)
+		}
+		traces = append(traces, &trace)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating assignment traces: %w", err)
+	}
+
+	return traces, nil
+}
@@ -0,0 +1,102 @@
+package pg
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Ownership struct {
+	psql sq.StatementBuilderType
+	pool *pgxpool.Pool
+}
+
+func NewOwnership(pool *pgxpool.Pool) *Ownership {
+	return &Ownership{
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		pool: pool,
+	}
+}
+
+func (o *Ownership) ReplaceAll(ctx context.Context, rules []*domain.OwnershipRule) error {
+	tx, err := o.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	deleteSql, deleteArgs, err := o.psql.Delete("ownership_rules").ToSql()
+	if err != nil {
+		return fmt.Errorf("error building delete query: %w", err)
+	}
+	_, err = tx.Exec(ctx, deleteSql, deleteArgs...)
+	if err != nil {
+		return fmt.Errorf("error removing ownership rules: %w", err)
+	}
+
+	if len(rules) > 0 {
+		insertQ := o.psql.Insert("ownership_rules").
+			Columns("position", "pattern", "owners")
+		for i, rule := range rules {
+			owners, err := json.Marshal(rule.Owners)
+			if err != nil {
+				return fmt.Errorf("error encoding owners: %w", err)
+			}
+			insertQ = insertQ.Values(i, rule.Pattern, owners)
+		}
+		insertSql, insertArgs, err := insertQ.ToSql()
+		if err != nil {
+			return fmt.Errorf("error building insert query: %w", err)
+		}
+		_, err = tx.Exec(ctx, insertSql, insertArgs...)
+		if err != nil {
+			return fmt.Errorf("error adding ownership rules: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (o *Ownership) GetAll(ctx context.Context) ([]*domain.OwnershipRule, error) {
+	q := o.psql.Select("pattern", "owners").
+		From("ownership_rules").
+		OrderBy("position")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := o.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying ownership rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []*domain.OwnershipRule{}
+	for rows.Next() {
+		var rule domain.OwnershipRule
+		var owners []byte
+		if err := rows.Scan(&rule.Pattern, &owners); err != nil {
+			return nil, fmt.Errorf("error scanning ownership rule: %w", err)
+		}
+		if err := json.Unmarshal(owners, &rule.Owners); err != nil {
+			return nil, fmt.Errorf("error decoding owners: %w", err)
+		}
+		rules = append(rules, &rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ownership rules: %w", err)
+	}
+
+	return rules, nil
+}
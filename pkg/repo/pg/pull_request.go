@@ -3,15 +3,21 @@ package pg
 import (
 	"Avito/pkg/domain"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// swapChunkSize keeps bulk reviewer statements well below the Postgres
+// limit on bind parameters.
+const swapChunkSize = 1000
+
 type PullRequest struct {
 	psql sq.StatementBuilderType
 	pool *pgxpool.Pool
@@ -25,6 +31,12 @@ func NewPullRequest(pool *pgxpool.Pool) *PullRequest {
 }
 
 func (p *PullRequest) Create(ctx context.Context, pr *domain.PullRequest) error {
+	return p.CreateWithTrace(ctx, pr, nil)
+}
+
+// CreateWithTrace stores pr with its reviewers, labels and, when set, the
+// assignment trace in one transaction.
+func (p *PullRequest) CreateWithTrace(ctx context.Context, pr *domain.PullRequest, trace *domain.AssignmentTrace) error {
 	tx, err := p.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -32,8 +44,8 @@ func (p *PullRequest) Create(ctx context.Context, pr *domain.PullRequest) error
 	defer tx.Rollback(ctx)
 
 	q := p.psql.Insert("pull_requests").
-		Columns("pull_request_id", "pull_request_name", "author_id", "status", "created_at").
-		Values(pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.CreatedAt)
+		Columns("pull_request_id", "pull_request_name", "author_id", "status", "under_reviewed", "created_at").
+		Values(pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.UnderReviewed, pr.CreatedAt)
 
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -61,6 +73,29 @@ func (p *PullRequest) Create(ctx context.Context, pr *domain.PullRequest) error
 		}
 	}
 
+	if len(pr.Labels) > 0 {
+		labelQ := p.psql.Insert("pr_labels").
+			Columns("pull_request_id", "label")
+
+		for _, label := range pr.Labels {
+			labelQ = labelQ.Values(pr.PullRequestID, label)
+		}
+		labelSql, labelArgs, err := labelQ.ToSql()
+		if err != nil {
+			return fmt.Errorf("error building labels query: %w", err)
+		}
+		_, err = tx.Exec(ctx, labelSql, labelArgs...)
+		if err != nil {
+			return fmt.Errorf("error adding labels: %w", err)
+		}
+	}
+
+	if trace != nil {
+		if err := insertAssignmentTrace(ctx, tx, p.psql, trace); err != nil {
+			return err
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -69,7 +104,7 @@ func (p *PullRequest) Create(ctx context.Context, pr *domain.PullRequest) error
 }
 
 func (p *PullRequest) GetByID(ctx context.Context, prID string) (*domain.PullRequest, error) {
-	q := p.psql.Select("pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at").
+	q := p.psql.Select("pull_request_id", "pull_request_name", "description", "author_id", "status", "metadata", "under_reviewed", "created_at", "merged_at", "closed_at").
 		From("pull_requests").
 		Where(sq.Eq{"pull_request_id": prID})
 
@@ -79,7 +114,7 @@ func (p *PullRequest) GetByID(ctx context.Context, prID string) (*domain.PullReq
 	}
 	var pr domain.PullRequest
 	err = p.pool.QueryRow(ctx, sql, args...).Scan(
-		&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt,
+		&pr.PullRequestID, &pr.PullRequestName, &pr.Description, &pr.AuthorID, &pr.Status, &pr.Metadata, &pr.UnderReviewed, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -95,7 +130,7 @@ func (p *PullRequest) GetByIDs(ctx context.Context, prIDs []string) ([]*domain.P
 		return []*domain.PullRequest{}, nil
 	}
 
-	q := p.psql.Select("pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at").
+	q := p.psql.Select("pull_request_id", "pull_request_name", "description", "author_id", "status", "metadata", "under_reviewed", "created_at", "merged_at", "closed_at").
 		From("pull_requests").
 		Where(sq.Eq{"pull_request_id": prIDs}).
 		OrderBy("created_at DESC")
@@ -114,7 +149,74 @@ func (p *PullRequest) GetByIDs(ctx context.Context, prIDs []string) ([]*domain.P
 	var prs []*domain.PullRequest
 	for rows.Next() {
 		var pr domain.PullRequest
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt); err != nil {
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.Description, &pr.AuthorID, &pr.Status, &pr.Metadata, &pr.UnderReviewed, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt); err != nil {
+			return nil, fmt.Errorf("error scanning pull request: %w", err)
+		}
+		prs = append(prs, &pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pull requests: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (p *PullRequest) GetOpenUnderReviewed(ctx context.Context) ([]*domain.PullRequest, error) {
+	q := p.psql.Select("pull_request_id", "pull_request_name", "description", "author_id", "status", "metadata", "under_reviewed", "created_at", "merged_at", "closed_at").
+		From("pull_requests").
+		Where(sq.Eq{"status": domain.PRStatusOpen, "under_reviewed": true}).
+		OrderBy("created_at", "pull_request_id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*domain.PullRequest
+	for rows.Next() {
+		var pr domain.PullRequest
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.Description, &pr.AuthorID, &pr.Status, &pr.Metadata, &pr.UnderReviewed, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt); err != nil {
+			return nil, fmt.Errorf("error scanning pull request: %w", err)
+		}
+		prs = append(prs, &pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pull requests: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (p *PullRequest) GetOpenByAuthorTeam(ctx context.Context, teamName string) ([]*domain.PullRequest, error) {
+	q := p.psql.Select("pr.pull_request_id", "pr.pull_request_name", "pr.description", "pr.author_id", "pr.status", "pr.metadata", "pr.under_reviewed", "pr.created_at", "pr.merged_at", "pr.closed_at").
+		From("pull_requests pr").
+		Join("users u ON u.user_id = pr.author_id").
+		Where(sq.Eq{"pr.status": domain.PRStatusOpen, "u.team_name": teamName}).
+		OrderBy("pr.created_at", "pr.pull_request_id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []*domain.PullRequest
+	for rows.Next() {
+		var pr domain.PullRequest
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.Description, &pr.AuthorID, &pr.Status, &pr.Metadata, &pr.UnderReviewed, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt); err != nil {
 			return nil, fmt.Errorf("error scanning pull request: %w", err)
 		}
 		prs = append(prs, &pr)
@@ -127,6 +229,105 @@ func (p *PullRequest) GetByIDs(ctx context.Context, prIDs []string) ([]*domain.P
 	return prs, nil
 }
 
+// Update applies patch to a PR that is not merged yet. The PR row is locked
+// so a concurrent merge cannot slip in between the check and the update.
+func (p *PullRequest) Update(ctx context.Context, prID string, patch *domain.PullRequestUpdate) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	lockQ := p.psql.Select("status").
+		From("pull_requests").
+		Where(sq.Eq{"pull_request_id": prID}).
+		Suffix("FOR UPDATE")
+
+	lockSql, lockArgs, err := lockQ.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+	var status domain.PRStatus
+	if err := tx.QueryRow(ctx, lockSql, lockArgs...).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &domain.DomainError{Code: domain.ErrNotFound, Message: "pull request not found"}
+		}
+		return fmt.Errorf("error locking pull request: %w", err)
+	}
+	if status == domain.PRStatusMerged {
+		return &domain.DomainError{Code: domain.ErrPRMerged, Message: "cannot update merged PR"}
+	}
+
+	q := p.psql.Update("pull_requests").Where(sq.Eq{"pull_request_id": prID})
+	changed := false
+	if patch.PullRequestName != nil {
+		q = q.Set("pull_request_name", *patch.PullRequestName)
+		changed = true
+	}
+	if patch.Description != nil {
+		q = q.Set("description", *patch.Description)
+		changed = true
+	}
+	if len(patch.Metadata) > 0 {
+		set := make(map[string]string, len(patch.Metadata))
+		removed := []string{}
+		for key, value := range patch.Metadata {
+			if value == nil {
+				removed = append(removed, key)
+				continue
+			}
+			set[key] = *value
+		}
+		setJSON, err := json.Marshal(set)
+		if err != nil {
+			return fmt.Errorf("error encoding metadata: %w", err)
+		}
+		q = q.Set("metadata", sq.Expr("(metadata || ?::jsonb) - ?::text[]", setJSON, removed))
+		changed = true
+	}
+	if changed {
+		sql, args, err := q.ToSql()
+		if err != nil {
+			return fmt.Errorf("error building query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("error updating pull request: %w", err)
+		}
+	}
+
+	if patch.Labels != nil {
+		deleteQ := p.psql.Delete("pr_labels").Where(sq.Eq{"pull_request_id": prID})
+		deleteSql, deleteArgs, err := deleteQ.ToSql()
+		if err != nil {
+			return fmt.Errorf("error building labels query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, deleteSql, deleteArgs...); err != nil {
+			return fmt.Errorf("error removing labels: %w", err)
+		}
+		if len(*patch.Labels) > 0 {
+			labelQ := p.psql.Insert("pr_labels").
+				Columns("pull_request_id", "label")
+
+			for _, label := range *patch.Labels {
+				labelQ = labelQ.Values(prID, label)
+			}
+			labelSql, labelArgs, err := labelQ.ToSql()
+			if err != nil {
+				return fmt.Errorf("error building labels query: %w", err)
+			}
+			if _, err := tx.Exec(ctx, labelSql, labelArgs...); err != nil {
+				return fmt.Errorf("error adding labels: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 func (p *PullRequest) AddReviewer(ctx context.Context, prID string, userID string) error {
 	q := p.psql.Insert("pr_reviewers").
 		Columns("pull_request_id", "user_id").
@@ -211,11 +412,206 @@ func (p *PullRequest) ReplaceReviewer(ctx context.Context, prID string, oldUserI
 	return nil
 }
 
+// ApplyRedistribution deactivates users, applies the swaps and updates the
+// under-reviewed flags in one transaction.
+func (p *PullRequest) ApplyRedistribution(ctx context.Context, redistribution *domain.ReviewRedistribution) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := p.applyRedistribution(ctx, tx, redistribution); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PullRequest) applyRedistribution(ctx context.Context, tx pgx.Tx, redistribution *domain.ReviewRedistribution) error {
+	if len(redistribution.Deactivate) > 0 {
+		sql, args, err := p.psql.Update("users").
+			Set("is_active", false).
+			Where(sq.Eq{"user_id": redistribution.Deactivate}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("error building query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf("error deactivating users: %w", err)
+		}
+	}
+
+	swaps := redistribution.Swaps
+	for start := 0; start < len(swaps); start += swapChunkSize {
+		chunk := swaps[start:min(start+swapChunkSize, len(swaps))]
+
+		removed := sq.Or{}
+		insertQ := p.psql.Insert("pr_reviewers").
+			Columns("pull_request_id", "user_id").
+			Suffix("ON CONFLICT (pull_request_id, user_id) DO NOTHING")
+		inserts := 0
+		for _, swap := range chunk {
+			removed = append(removed, sq.Eq{"pull_request_id": swap.PullRequestID, "user_id": swap.FromUserID})
+			if swap.ToUserID != "" {
+				insertQ = insertQ.Values(swap.PullRequestID, swap.ToUserID)
+				inserts++
+			}
+		}
+
+		deleteSql, deleteArgs, err := p.psql.Delete("pr_reviewers").Where(removed).ToSql()
+		if err != nil {
+			return fmt.Errorf("error building delete query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, deleteSql, deleteArgs...); err != nil {
+			return fmt.Errorf("error removing reviewers: %w", err)
+		}
+
+		if inserts == 0 {
+			continue
+		}
+		insertSql, insertArgs, err := insertQ.ToSql()
+		if err != nil {
+			return fmt.Errorf("error building insert query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, insertSql, insertArgs...); err != nil {
+			return fmt.Errorf("error adding reviewers: %w", err)
+		}
+	}
+
+	for _, trace := range redistribution.Traces {
+		if err := insertAssignmentTrace(ctx, tx, p.psql, trace); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range redistribution.Changes {
+		if err := p.applyReviewerChange(ctx, tx, change); err != nil {
+			return err
+		}
+	}
+
+	if err := setUnderReviewedMany(ctx, tx, p.psql, redistribution.NowUnderReviewed, true); err != nil {
+		return err
+	}
+	if err := setUnderReviewedMany(ctx, tx, p.psql, redistribution.NowReviewed, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ApplyReviewerChange removes and adds reviewers, updates the under-reviewed
+// flag and stores the trace in one transaction. Removing a reviewer who is
+// not assigned fails with NOT_ASSIGNED.
+func (p *PullRequest) ApplyReviewerChange(ctx context.Context, change *domain.ReviewerChange) error {
+	return p.ApplyReviewerChanges(ctx, []*domain.ReviewerChange{change})
+}
+
+// ApplyReviewerChanges applies all changes in one transaction, so either
+// every PR is updated or none is.
+func (p *PullRequest) ApplyReviewerChanges(ctx context.Context, changes []*domain.ReviewerChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, change := range changes {
+		if err := p.applyReviewerChange(ctx, tx, change); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PullRequest) applyReviewerChange(ctx context.Context, tx pgx.Tx, change *domain.ReviewerChange) error {
+	for _, userID := range change.Removed {
+		deleteSql, deleteArgs, err := p.psql.Delete("pr_reviewers").
+			Where(sq.Eq{"pull_request_id": change.PullRequestID, "user_id": userID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("error building delete query: %w", err)
+		}
+		result, err := tx.Exec(ctx, deleteSql, deleteArgs...)
+		if err != nil {
+			return fmt.Errorf("error removing reviewer: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return &domain.DomainError{Code: domain.ErrNotAssigned, Message: "reviewer is not assigned to this PR"}
+		}
+	}
+
+	if len(change.Added) > 0 {
+		insertQ := p.psql.Insert("pr_reviewers").
+			Columns("pull_request_id", "user_id").
+			Suffix("ON CONFLICT (pull_request_id, user_id) DO NOTHING")
+		for _, userID := range change.Added {
+			insertQ = insertQ.Values(change.PullRequestID, userID)
+		}
+		insertSql, insertArgs, err := insertQ.ToSql()
+		if err != nil {
+			return fmt.Errorf("error building insert query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, insertSql, insertArgs...); err != nil {
+			return fmt.Errorf("error adding reviewers: %w", err)
+		}
+	}
+
+	if change.UnderReviewed != nil {
+		updateSql, updateArgs, err := p.psql.Update("pull_requests").
+			Set("under_reviewed", *change.UnderReviewed).
+			Where(sq.Eq{"pull_request_id": change.PullRequestID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("error building update query: %w", err)
+		}
+		if _, err := tx.Exec(ctx, updateSql, updateArgs...); err != nil {
+			return fmt.Errorf("error setting under-reviewed flag: %w", err)
+		}
+	}
+
+	if change.Trace != nil {
+		if err := insertAssignmentTrace(ctx, tx, p.psql, change.Trace); err != nil {
+			return err
+		}
+	}
+
+	if change.Reassignment != nil {
+		if err := insertReassignment(ctx, tx, p.psql, change.Reassignment); err != nil {
+			return err
+		}
+	}
+
+	if change.Decline != nil {
+		if err := insertReviewDecline(ctx, tx, p.psql, change.Decline); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetMerged merges prID only while it is still open, so a PR closed or
+// turned into a draft after the approval check is not merged.
 func (p *PullRequest) SetMerged(ctx context.Context, prID string) error {
 	q := p.psql.Update("pull_requests").
 		Set("status", domain.PRStatusMerged).
 		Set("merged_at", time.Now()).
-		Where(sq.Eq{"pull_request_id": prID})
+		Where(sq.Eq{"pull_request_id": prID, "status": domain.PRStatusOpen})
 
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -228,7 +624,272 @@ func (p *PullRequest) SetMerged(ctx context.Context, prID string) error {
 	}
 
 	if result.RowsAffected() == 0 {
-		return &domain.DomainError{Code: domain.ErrNotFound, Message: "pull request not found"}
+		return statusConflict(ctx, p.pool, p.psql, prID)
+	}
+
+	return nil
+}
+
+// SetClosed closes prID and remembers whether it was a draft, so that
+// SetReopened can restore it.
+func (p *PullRequest) SetClosed(ctx context.Context, prID string) error {
+	q := p.psql.Update("pull_requests").
+		Set("status", domain.PRStatusClosed).
+		Set("closed_at", time.Now()).
+		Set("closed_from_draft", sq.Expr("status = ?", domain.PRStatusDraft)).
+		Where(sq.Eq{"pull_request_id": prID, "status": []domain.PRStatus{domain.PRStatusOpen, domain.PRStatusDraft}})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	result, err := p.pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("error setting closed status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return statusConflict(ctx, p.pool, p.psql, prID)
+	}
+
+	return nil
+}
+
+// SetReopened moves a closed PR back to OPEN, or to DRAFT when it was
+// closed as a draft, and applies redistribution, when set, in the same
+// transaction.
+func (p *PullRequest) SetReopened(ctx context.Context, prID string, redistribution *domain.ReviewRedistribution) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := p.psql.Update("pull_requests").
+		Set("status", sq.Expr("CASE WHEN closed_from_draft THEN ? ELSE ? END", domain.PRStatusDraft, domain.PRStatusOpen)).
+		Set("closed_from_draft", false).
+		Set("closed_at", nil).
+		Where(sq.Eq{"pull_request_id": prID, "status": domain.PRStatusClosed})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("error setting open status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return &domain.DomainError{Code: domain.ErrNotFound, Message: "closed pull request not found"}
+	}
+
+	if redistribution != nil {
+		if err := p.applyRedistribution(ctx, tx, redistribution); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// statusConflict explains why a status change guarded by the current status
+// matched no row: the PR is missing or already merged or closed.
+func statusConflict(ctx context.Context, db queryRower, psql sq.StatementBuilderType, prID string) error {
+	sql, args, err := psql.Select("status").
+		From("pull_requests").
+		Where(sq.Eq{"pull_request_id": prID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+	var status domain.PRStatus
+	if err := db.QueryRow(ctx, sql, args...).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &domain.DomainError{Code: domain.ErrNotFound, Message: "pull request not found"}
+		}
+		return fmt.Errorf("error getting pull request status: %w", err)
+	}
+	switch status {
+	case domain.PRStatusMerged:
+		return &domain.DomainError{Code: domain.ErrPRMerged, Message: "pull request is already merged"}
+	case domain.PRStatusClosed:
+		return &domain.DomainError{Code: domain.ErrPRClosed, Message: "pull request is closed"}
+	case domain.PRStatusDraft:
+		return &domain.DomainError{Code: domain.ErrPRDraft, Message: "pull request is a draft"}
+	}
+	return fmt.Errorf("pull request %s changed to %s concurrently", prID, status)
+}
+
+// MarkReady moves a draft to OPEN and assigns its first reviewers in one
+// transaction, storing trace with them when set.
+func (p *PullRequest) MarkReady(ctx context.Context, prID string, reviewers []string, underReviewed bool, trace *domain.AssignmentTrace) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := p.psql.Update("pull_requests").
+		Set("status", domain.PRStatusOpen).
+		Set("under_reviewed", underReviewed).
+		Where(sq.Eq{"pull_request_id": prID, "status": domain.PRStatusDraft})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+	result, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("error setting open status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return &domain.DomainError{Code: domain.ErrNotFound, Message: "draft pull request not found"}
+	}
+
+	if len(reviewers) > 0 {
+		reviewerQ := p.psql.Insert("pr_reviewers").
+			Columns("pull_request_id", "user_id")
+
+		for _, reviewerID := range reviewers {
+			reviewerQ = reviewerQ.Values(prID, reviewerID)
+		}
+		reviewerSql, reviewerArgs, err := reviewerQ.ToSql()
+		if err != nil {
+			return fmt.Errorf("error building reviewers query: %w", err)
+		}
+		_, err = tx.Exec(ctx, reviewerSql, reviewerArgs...)
+		if err != nil {
+			return fmt.Errorf("error adding reviewers: %w", err)
+		}
+	}
+
+	if trace != nil {
+		if err := insertAssignmentTrace(ctx, tx, p.psql, trace); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SetForceMerged merges an open PR and records the admin override that
+// let it skip the approval gate.
+func (p *PullRequest) SetForceMerged(ctx context.Context, override *domain.MergeOverride) error {
+	approvedBy, err := json.Marshal(override.ApprovedBy)
+	if err != nil {
+		return fmt.Errorf("error encoding approvals: %w", err)
+	}
+	changesRequestedBy, err := json.Marshal(override.ChangesRequestedBy)
+	if err != nil {
+		return fmt.Errorf("error encoding change requests: %w", err)
+	}
+
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	q := p.psql.Update("pull_requests").
+		Set("status", domain.PRStatusMerged).
+		Set("merged_at", override.CreatedAt).
+		Where(sq.Eq{"pull_request_id": override.PullRequestID, "status": domain.PRStatusOpen})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+	result, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("error setting merged status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return statusConflict(ctx, tx, p.psql, override.PullRequestID)
+	}
+
+	overrideQ := p.psql.Insert("merge_overrides").
+		Columns("pull_request_id", "user_id", "reason", "required_approvals", "approved_by", "changes_requested_by", "created_at").
+		Values(override.PullRequestID, override.UserID, override.Reason, override.RequiredApprovals, approvedBy, changesRequestedBy, override.CreatedAt).
+		Suffix("RETURNING id")
+
+	overrideSql, overrideArgs, err := overrideQ.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building override query: %w", err)
+	}
+	if err := tx.QueryRow(ctx, overrideSql, overrideArgs...).Scan(&override.ID); err != nil {
+		return fmt.Errorf("error recording merge override: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PullRequest) GetMergeOverride(ctx context.Context, prID string) (*domain.MergeOverride, error) {
+	q := p.psql.Select("id", "pull_request_id", "user_id", "reason", "required_approvals", "approved_by", "changes_requested_by", "created_at").
+		From("merge_overrides").
+		Where(sq.Eq{"pull_request_id": prID})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	var override domain.MergeOverride
+	var approvedBy, changesRequestedBy []byte
+	err = p.pool.QueryRow(ctx, sql, args...).Scan(
+		&override.ID, &override.PullRequestID, &override.UserID, &override.Reason, &override.RequiredApprovals,
+		&approvedBy, &changesRequestedBy, &override.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &domain.DomainError{Code: domain.ErrNotFound, Message: "merge override not found"}
+		}
+		return nil, fmt.Errorf("error getting merge override: %w", err)
+	}
+	if err := json.Unmarshal(approvedBy, &override.ApprovedBy); err != nil {
+		return nil, fmt.Errorf("error decoding approvals: %w", err)
+	}
+	if err := json.Unmarshal(changesRequestedBy, &override.ChangesRequestedBy); err != nil {
+		return nil, fmt.Errorf("error decoding change requests: %w", err)
+	}
+	return &override, nil
+}
+
+// execer is satisfied by both the pool and a transaction.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+func setUnderReviewedMany(ctx context.Context, db execer, psql sq.StatementBuilderType, prIDs []string, underReviewed bool) error {
+	if len(prIDs) == 0 {
+		return nil
+	}
+
+	q := psql.Update("pull_requests").
+		Set("under_reviewed", underReviewed).
+		Where(sq.Eq{"pull_request_id": prIDs})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	if _, err := db.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("error setting under-reviewed flags: %w", err)
 	}
 
 	return nil
@@ -267,6 +928,76 @@ func (p *PullRequest) GetReviewers(ctx context.Context, prID string) ([]string,
 	return reviewers, nil
 }
 
+func (p *PullRequest) GetReviewersByPRs(ctx context.Context, prIDs []string) (map[string][]string, error) {
+	reviewers := make(map[string][]string, len(prIDs))
+	if len(prIDs) == 0 {
+		return reviewers, nil
+	}
+
+	q := p.psql.Select("pull_request_id", "user_id").
+		From("pr_reviewers").
+		Where(sq.Eq{"pull_request_id": prIDs}).
+		OrderBy("pull_request_id", "user_id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID, reviewerID string
+		if err := rows.Scan(&prID, &reviewerID); err != nil {
+			return nil, fmt.Errorf("error scanning reviewer: %w", err)
+		}
+		reviewers[prID] = append(reviewers[prID], reviewerID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviewers: %w", err)
+	}
+
+	return reviewers, nil
+}
+
+func (p *PullRequest) GetLabels(ctx context.Context, prID string) ([]string, error) {
+	q := p.psql.Select("label").
+		From("pr_labels").
+		Where(sq.Eq{"pull_request_id": prID}).
+		OrderBy("label")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying labels: %w", err)
+	}
+	defer rows.Close()
+
+	var labels []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, fmt.Errorf("error scanning label: %w", err)
+		}
+		labels = append(labels, label)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating labels: %w", err)
+	}
+
+	return labels, nil
+}
+
 func (p *PullRequest) GetPRIDsByReviewer(ctx context.Context, userID string) ([]string, error) {
 	q := p.psql.Select("pull_request_id").
 		From("pr_reviewers").
@@ -300,6 +1031,132 @@ func (p *PullRequest) GetPRIDsByReviewer(ctx context.Context, userID string) ([]
 	return prIDs, nil
 }
 
+func (p *PullRequest) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	q := p.psql.Select("r.user_id", "COUNT(*)").
+		From("pr_reviewers r").
+		Join("pull_requests pr ON pr.pull_request_id = r.pull_request_id").
+		Where(sq.Eq{"r.user_id": userIDs, "pr.status": domain.PRStatusOpen}).
+		GroupBy("r.user_id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying open review counts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("error scanning open review count: %w", err)
+		}
+		counts[userID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open review counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetOpenAssignments returns the open reviews of userIDs, newest first. A
+// positive maxAge skips older assignments; with unreviewedOnly, assignments
+// whose reviewer already submitted a verdict are skipped too.
+func (p *PullRequest) GetOpenAssignments(ctx context.Context, userIDs []string, maxAge time.Duration, unreviewedOnly bool) ([]*domain.ReviewAssignment, error) {
+	if len(userIDs) == 0 {
+		return []*domain.ReviewAssignment{}, nil
+	}
+
+	q := p.psql.Select("r.pull_request_id", "pr.author_id", "r.user_id", "r.assigned_at").
+		From("pr_reviewers r").
+		Join("pull_requests pr ON pr.pull_request_id = r.pull_request_id").
+		Where(sq.Eq{"r.user_id": userIDs, "pr.status": domain.PRStatusOpen}).
+		OrderBy("r.assigned_at DESC", "r.pull_request_id")
+	if maxAge > 0 {
+		q = q.Where("r.assigned_at >= NOW() - make_interval(secs => ?)", maxAge.Seconds())
+	}
+	if unreviewedOnly {
+		q = q.Where("NOT EXISTS (SELECT 1 FROM review_verdicts v WHERE v.pull_request_id = r.pull_request_id AND v.user_id = r.user_id)")
+	}
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying open assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []*domain.ReviewAssignment{}
+	for rows.Next() {
+		var assignment domain.ReviewAssignment
+		if err := rows.Scan(&assignment.PullRequestID, &assignment.AuthorID, &assignment.UserID, &assignment.AssignedAt); err != nil {
+			return nil, fmt.Errorf("error scanning open assignment: %w", err)
+		}
+		assignments = append(assignments, &assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+func (p *PullRequest) GetLastReviewersByAuthor(ctx context.Context, authorID string, excludePRID string) ([]string, error) {
+	lastPR := sq.Select("pull_request_id").
+		From("pull_requests").
+		Where(sq.Eq{"author_id": authorID}).
+		Where(sq.NotEq{"pull_request_id": excludePRID}).
+		OrderBy("created_at DESC").
+		Limit(1)
+
+	q := p.psql.Select("user_id").
+		From("pr_reviewers").
+		Where(lastPR.Prefix("pull_request_id = (").Suffix(")")).
+		OrderBy("user_id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := p.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying last reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	var reviewers []string
+	for rows.Next() {
+		var reviewerID string
+		if err := rows.Scan(&reviewerID); err != nil {
+			return nil, fmt.Errorf("error scanning reviewer: %w", err)
+		}
+		reviewers = append(reviewers, reviewerID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating last reviewers: %w", err)
+	}
+
+	return reviewers, nil
+}
+
 func (p *PullRequest) Exists(ctx context.Context, prID string) (bool, error) {
 	q := p.psql.Select("1").
 		From("pull_requests").
@@ -0,0 +1,81 @@
+package pg
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Reassignment struct {
+	psql sq.StatementBuilderType
+	pool *pgxpool.Pool
+}
+
+func NewReassignment(pool *pgxpool.Pool) *Reassignment {
+	return &Reassignment{
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		pool: pool,
+	}
+}
+
+func (r *Reassignment) Create(ctx context.Context, reassignment *domain.Reassignment) error {
+	return insertReassignment(ctx, r.pool, r.psql, reassignment)
+}
+
+func insertReassignment(ctx context.Context, db queryRower, psql sq.StatementBuilderType, reassignment *domain.Reassignment) error {
+	q := psql.Insert("reassignments").
+		Columns("pull_request_id", "team_name", "from_user_id", "to_user_id", "reason", "created_at").
+		Values(reassignment.PullRequestID, reassignment.TeamName, reassignment.FromUserID, reassignment.ToUserID, reassignment.Reason, reassignment.CreatedAt).
+		Suffix("RETURNING id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	err = db.QueryRow(ctx, sql, args...).Scan(&reassignment.ID)
+	if err != nil {
+		return fmt.Errorf("error creating reassignment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reassignment) ListRecent(ctx context.Context, limit int) ([]*domain.Reassignment, error) {
+	q := r.psql.Select("id", "pull_request_id", "team_name", "from_user_id", "to_user_id", "reason", "created_at").
+		From("reassignments").
+		OrderBy("id DESC").
+		Limit(uint64(limit))
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying reassignments: %w", err)
+	}
+	defer rows.Close()
+
+	reassignments := []*domain.Reassignment{}
+	for rows.Next() {
+		var reassignment domain.Reassignment
+		if err := rows.Scan(
+			&reassignment.ID, &reassignment.PullRequestID, &reassignment.TeamName,
+			&reassignment.FromUserID, &reassignment.ToUserID, &reassignment.Reason, &reassignment.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning reassignment: %w", err)
+		}
+		reassignments = append(reassignments, &reassignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reassignments: %w", err)
+	}
+
+	return reassignments, nil
+}
@@ -0,0 +1,75 @@
+package pg
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Review struct {
+	psql sq.StatementBuilderType
+	pool *pgxpool.Pool
+}
+
+func NewReview(pool *pgxpool.Pool) *Review {
+	return &Review{
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		pool: pool,
+	}
+}
+
+func (r *Review) Create(ctx context.Context, review *domain.Review) error {
+	q := r.psql.Insert("review_verdicts").
+		Columns("pull_request_id", "user_id", "verdict", "body", "created_at").
+		Values(review.PullRequestID, review.UserID, review.Verdict, review.Body, review.CreatedAt).
+		Suffix("RETURNING id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	err = r.pool.QueryRow(ctx, sql, args...).Scan(&review.ID)
+	if err != nil {
+		return fmt.Errorf("error creating review: %w", err)
+	}
+
+	return nil
+}
+
+// GetByPullRequestID returns the verdict history of prID, oldest first.
+func (r *Review) GetByPullRequestID(ctx context.Context, prID string) ([]*domain.Review, error) {
+	q := r.psql.Select("id", "pull_request_id", "user_id", "verdict", "body", "created_at").
+		From("review_verdicts").
+		Where(sq.Eq{"pull_request_id": prID}).
+		OrderBy("created_at", "id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*domain.Review{}
+	for rows.Next() {
+		var review domain.Review
+		if err := rows.Scan(&review.ID, &review.PullRequestID, &review.UserID, &review.Verdict, &review.Body, &review.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning review: %w", err)
+		}
+		reviews = append(reviews, &review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviews: %w", err)
+	}
+
+	return reviews, nil
+}
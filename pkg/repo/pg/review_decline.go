@@ -0,0 +1,87 @@
+package pg
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReviewDecline struct {
+	psql sq.StatementBuilderType
+	pool *pgxpool.Pool
+}
+
+func NewReviewDecline(pool *pgxpool.Pool) *ReviewDecline {
+	return &ReviewDecline{
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		pool: pool,
+	}
+}
+
+func (r *ReviewDecline) Create(ctx context.Context, decline *domain.ReviewDecline) error {
+	return insertReviewDecline(ctx, r.pool, r.psql, decline)
+}
+
+func insertReviewDecline(ctx context.Context, db queryRower, psql sq.StatementBuilderType, decline *domain.ReviewDecline) error {
+	q := psql.Insert("review_declines").
+		Columns("pull_request_id", "user_id", "reason", "comment", "replaced_by", "created_at").
+		Values(decline.PullRequestID, decline.UserID, decline.Reason, decline.Comment, nullableString(decline.ReplacedBy), decline.CreatedAt).
+		Suffix("RETURNING id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	err = db.QueryRow(ctx, sql, args...).Scan(&decline.ID)
+	if err != nil {
+		return fmt.Errorf("error creating review decline: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReviewDecline) GetCountsByUsers(ctx context.Context, userIDs []string) (map[string]map[domain.DeclineReason]int, error) {
+	counts := make(map[string]map[domain.DeclineReason]int, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	q := r.psql.Select("user_id", "reason", "COUNT(*)").
+		From("review_declines").
+		Where(sq.Eq{"user_id": userIDs}).
+		GroupBy("user_id", "reason")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying decline counts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var reason domain.DeclineReason
+		var count int
+		if err := rows.Scan(&userID, &reason, &count); err != nil {
+			return nil, fmt.Errorf("error scanning decline count: %w", err)
+		}
+		if counts[userID] == nil {
+			counts[userID] = make(map[domain.DeclineReason]int)
+		}
+		counts[userID][reason] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating decline counts: %w", err)
+	}
+
+	return counts, nil
+}
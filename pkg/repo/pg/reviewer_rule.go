@@ -0,0 +1,113 @@
+package pg
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReviewerRule struct {
+	psql sq.StatementBuilderType
+	pool *pgxpool.Pool
+}
+
+func NewReviewerRule(pool *pgxpool.Pool) *ReviewerRule {
+	return &ReviewerRule{
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		pool: pool,
+	}
+}
+
+func (r *ReviewerRule) Create(ctx context.Context, rule *domain.ReviewerRule) error {
+	q := r.psql.Insert("reviewer_rules").
+		Columns("rule_type", "author_id", "reviewer_id", "author_level", "reviewer_level", "created_at").
+		Values(rule.Type, nullableString(rule.AuthorID), nullableString(rule.ReviewerID), nullableString(string(rule.AuthorLevel)), nullableString(string(rule.ReviewerLevel)), rule.CreatedAt).
+		Suffix("RETURNING id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	err = r.pool.QueryRow(ctx, sql, args...).Scan(&rule.ID)
+	if err != nil {
+		return fmt.Errorf("error creating reviewer rule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReviewerRule) Delete(ctx context.Context, ruleID int64) error {
+	q := r.psql.Delete("reviewer_rules").
+		Where(sq.Eq{"id": ruleID})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	result, err := r.pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("error deleting reviewer rule: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return &domain.DomainError{Code: domain.ErrNotFound, Message: "reviewer rule not found"}
+	}
+
+	return nil
+}
+
+func (r *ReviewerRule) List(ctx context.Context) ([]*domain.ReviewerRule, error) {
+	return r.query(ctx, r.selectRules())
+}
+
+func (r *ReviewerRule) GetForAuthor(ctx context.Context, authorID string) ([]*domain.ReviewerRule, error) {
+	q := r.selectRules().
+		Where(sq.Or{sq.Eq{"author_id": authorID}, sq.Eq{"author_id": nil}})
+	return r.query(ctx, q)
+}
+
+func (r *ReviewerRule) selectRules() sq.SelectBuilder {
+	return r.psql.Select("id", "rule_type", "COALESCE(author_id, '')", "COALESCE(reviewer_id, '')", "COALESCE(author_level, '')", "COALESCE(reviewer_level, '')", "created_at").
+		From("reviewer_rules").
+		OrderBy("id")
+}
+
+func (r *ReviewerRule) query(ctx context.Context, q sq.SelectBuilder) ([]*domain.ReviewerRule, error) {
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying reviewer rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []*domain.ReviewerRule{}
+	for rows.Next() {
+		var rule domain.ReviewerRule
+		if err := rows.Scan(&rule.ID, &rule.Type, &rule.AuthorID, &rule.ReviewerID, &rule.AuthorLevel, &rule.ReviewerLevel, &rule.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning reviewer rule: %w", err)
+		}
+		rules = append(rules, &rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviewer rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
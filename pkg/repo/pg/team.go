@@ -26,6 +26,11 @@ func (t *Team) Create(ctx context.Context, team *domain.Team) error {
 	q := t.psql.Insert("teams").
 		Columns("team_name").
 		Values(team.TeamName)
+	if team.ReviewerStrategy != "" {
+		q = t.psql.Insert("teams").
+			Columns("team_name", "reviewer_strategy").
+			Values(team.TeamName, team.ReviewerStrategy)
+	}
 
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -41,7 +46,7 @@ func (t *Team) Create(ctx context.Context, team *domain.Team) error {
 }
 
 func (t *Team) GetByName(ctx context.Context, teamName string) (*domain.Team, error) {
-	q := t.psql.Select("team_name").
+	q := t.psql.Select("team_name", "reviewer_strategy").
 		From("teams").
 		Where(sq.Eq{"team_name": teamName})
 
@@ -51,7 +56,7 @@ func (t *Team) GetByName(ctx context.Context, teamName string) (*domain.Team, er
 	}
 
 	var team domain.Team
-	err = t.pool.QueryRow(ctx, sql, args...).Scan(&team.TeamName)
+	err = t.pool.QueryRow(ctx, sql, args...).Scan(&team.TeamName, &team.ReviewerStrategy)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, &domain.DomainError{Code: domain.ErrNotFound, Message: "team not found"}
@@ -61,6 +66,175 @@ func (t *Team) GetByName(ctx context.Context, teamName string) (*domain.Team, er
 	return &team, nil
 }
 
+func (t *Team) SetReviewerStrategy(ctx context.Context, teamName string, strategy domain.ReviewerStrategy) error {
+	q := t.psql.Update("teams").
+		Set("reviewer_strategy", strategy).
+		Where(sq.Eq{"team_name": teamName})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	result, err := t.pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("error setting reviewer strategy: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return &domain.DomainError{Code: domain.ErrNotFound, Message: "team not found"}
+	}
+
+	return nil
+}
+
+func (t *Team) GetRoundRobinCursor(ctx context.Context, teamName string) (string, error) {
+	q := t.psql.Select("last_user_id").
+		From("team_round_robin_cursors").
+		Where(sq.Eq{"team_name": teamName})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return "", fmt.Errorf("error building query: %w", err)
+	}
+
+	var lastUserID string
+	err = t.pool.QueryRow(ctx, sql, args...).Scan(&lastUserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("error getting round-robin cursor: %w", err)
+	}
+	return lastUserID, nil
+}
+
+func (t *Team) SetRoundRobinCursor(ctx context.Context, teamName string, lastUserID string) error {
+	q := t.psql.Insert("team_round_robin_cursors").
+		Columns("team_name", "last_user_id").
+		Values(teamName, lastUserID).
+		Suffix("ON CONFLICT (team_name) DO UPDATE SET last_user_id = EXCLUDED.last_user_id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	_, err = t.pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("error setting round-robin cursor: %w", err)
+	}
+
+	return nil
+}
+
+func (t *Team) SetFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error {
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	deleteQ := t.psql.Delete("team_fallbacks").
+		Where(sq.Eq{"team_name": teamName})
+
+	deleteSql, deleteArgs, err := deleteQ.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building delete query: %w", err)
+	}
+	_, err = tx.Exec(ctx, deleteSql, deleteArgs...)
+	if err != nil {
+		return fmt.Errorf("error removing fallback teams: %w", err)
+	}
+
+	if len(fallbackTeams) > 0 {
+		insertQ := t.psql.Insert("team_fallbacks").
+			Columns("team_name", "fallback_team_name", "position")
+		for i, fallbackTeam := range fallbackTeams {
+			insertQ = insertQ.Values(teamName, fallbackTeam, i)
+		}
+		insertSql, insertArgs, err := insertQ.ToSql()
+		if err != nil {
+			return fmt.Errorf("error building insert query: %w", err)
+		}
+		_, err = tx.Exec(ctx, insertSql, insertArgs...)
+		if err != nil {
+			return fmt.Errorf("error adding fallback teams: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (t *Team) GetAllNames(ctx context.Context) ([]string, error) {
+	q := t.psql.Select("team_name").
+		From("teams").
+		OrderBy("team_name")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := t.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying teams: %w", err)
+	}
+	defer rows.Close()
+
+	var teamNames []string
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			return nil, fmt.Errorf("error scanning team: %w", err)
+		}
+		teamNames = append(teamNames, teamName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating teams: %w", err)
+	}
+
+	return teamNames, nil
+}
+
+func (t *Team) GetFallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	q := t.psql.Select("fallback_team_name").
+		From("team_fallbacks").
+		Where(sq.Eq{"team_name": teamName}).
+		OrderBy("position")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := t.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying fallback teams: %w", err)
+	}
+	defer rows.Close()
+
+	var fallbackTeams []string
+	for rows.Next() {
+		var fallbackTeam string
+		if err := rows.Scan(&fallbackTeam); err != nil {
+			return nil, fmt.Errorf("error scanning fallback team: %w", err)
+		}
+		fallbackTeams = append(fallbackTeams, fallbackTeam)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating fallback teams: %w", err)
+	}
+
+	return fallbackTeams, nil
+}
+
 func (t *Team) Exists(ctx context.Context, teamName string) (bool, error) {
 	q := t.psql.Select("1").
 		From("teams").
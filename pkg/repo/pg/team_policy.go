@@ -0,0 +1,75 @@
+package pg
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TeamPolicy struct {
+	psql sq.StatementBuilderType
+	pool *pgxpool.Pool
+}
+
+func NewTeamPolicy(pool *pgxpool.Pool) *TeamPolicy {
+	return &TeamPolicy{
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		pool: pool,
+	}
+}
+
+func (t *TeamPolicy) Upsert(ctx context.Context, policy *domain.TeamPolicy) error {
+	q := t.psql.Insert("team_policies").
+		Columns("team_name", "min_reviewers", "max_reviewers", "allow_cross_team", "require_senior", "working_hours_mode", "start_within_hours", "max_open_reviews", "required_approvals").
+		Values(policy.TeamName, policy.MinReviewers, policy.MaxReviewers, policy.AllowCrossTeam, policy.RequireSenior, policy.WorkingHoursMode, policy.StartWithinHours, policy.MaxOpenReviews, policy.RequiredApprovals).
+		Suffix(`ON CONFLICT (team_name) DO UPDATE SET
+			min_reviewers = EXCLUDED.min_reviewers,
+			max_reviewers = EXCLUDED.max_reviewers,
+			allow_cross_team = EXCLUDED.allow_cross_team,
+			require_senior = EXCLUDED.require_senior,
+			working_hours_mode = EXCLUDED.working_hours_mode,
+			start_within_hours = EXCLUDED.start_within_hours,
+			max_open_reviews = EXCLUDED.max_open_reviews,
+			required_approvals = EXCLUDED.required_approvals`)
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building query: %w", err)
+	}
+
+	_, err = t.pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("error saving team policy: %w", err)
+	}
+
+	return nil
+}
+
+func (t *TeamPolicy) GetByTeamName(ctx context.Context, teamName string) (*domain.TeamPolicy, error) {
+	q := t.psql.Select("team_name", "min_reviewers", "max_reviewers", "allow_cross_team", "require_senior", "working_hours_mode", "start_within_hours", "max_open_reviews", "required_approvals").
+		From("team_policies").
+		Where(sq.Eq{"team_name": teamName})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	var policy domain.TeamPolicy
+	err = t.pool.QueryRow(ctx, sql, args...).Scan(
+		&policy.TeamName, &policy.MinReviewers, &policy.MaxReviewers, &policy.AllowCrossTeam, &policy.RequireSenior,
+		&policy.WorkingHoursMode, &policy.StartWithinHours, &policy.MaxOpenReviews, &policy.RequiredApprovals,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &domain.DomainError{Code: domain.ErrNotFound, Message: "team policy not found"}
+		}
+		return nil, fmt.Errorf("error getting team policy: %w", err)
+	}
+	return &policy, nil
+}
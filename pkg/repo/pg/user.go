@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
@@ -24,9 +25,15 @@ func NewUser(pool *pgxpool.Pool) *User {
 }
 
 func (u *User) Create(ctx context.Context, user *domain.User) error {
+	columns := []string{"user_id", "username", "team_name", "is_active", "timezone", "work_start", "work_end", "max_open_reviews"}
+	values := []interface{}{user.UserID, user.Username, user.TeamName, user.IsActive, user.Timezone, user.WorkStart, user.WorkEnd, user.MaxOpenReviews}
+	if user.Level != "" {
+		columns = append(columns, "level")
+		values = append(values, user.Level)
+	}
 	q := u.psql.Insert("users").
-		Columns("user_id", "username", "team_name", "is_active").
-		Values(user.UserID, user.Username, user.TeamName, user.IsActive)
+		Columns(columns...).
+		Values(values...)
 	sql, args, err := q.ToSql()
 	if err != nil {
 		return fmt.Errorf("error building query: %w", err)
@@ -42,7 +49,7 @@ func (u *User) Create(ctx context.Context, user *domain.User) error {
 
 func (u *User) Update(ctx context.Context, userID string, patch *domain.UserUpdate) (*domain.User, error) {
 	q := u.psql.Update("users").
-		Suffix("RETURNING user_id, username, team_name, is_active")
+		Suffix("RETURNING user_id, username, team_name, is_active, level, timezone, work_start, work_end, max_open_reviews")
 	if patch.Username != nil {
 		q = q.Set("username", *patch.Username)
 	}
@@ -52,6 +59,21 @@ func (u *User) Update(ctx context.Context, userID string, patch *domain.UserUpda
 	if patch.IsActive != nil {
 		q = q.Set("is_active", *patch.IsActive)
 	}
+	if patch.Level != nil {
+		q = q.Set("level", *patch.Level)
+	}
+	if patch.Timezone != nil {
+		q = q.Set("timezone", *patch.Timezone)
+	}
+	if patch.WorkStart != nil {
+		q = q.Set("work_start", *patch.WorkStart)
+	}
+	if patch.WorkEnd != nil {
+		q = q.Set("work_end", *patch.WorkEnd)
+	}
+	if patch.MaxOpenReviews != nil {
+		q = q.Set("max_open_reviews", *patch.MaxOpenReviews)
+	}
 	q = q.Where(sq.Eq{"user_id": userID})
 	sql, args, err := q.ToSql()
 	if err != nil {
@@ -60,7 +82,8 @@ func (u *User) Update(ctx context.Context, userID string, patch *domain.UserUpda
 
 	var user domain.User
 	err = u.pool.QueryRow(ctx, sql, args...).Scan(
-		&user.UserID, &user.Username, &user.TeamName, &user.IsActive,
+		&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Level,
+		&user.Timezone, &user.WorkStart, &user.WorkEnd, &user.MaxOpenReviews,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -74,7 +97,7 @@ func (u *User) Update(ctx context.Context, userID string, patch *domain.UserUpda
 }
 
 func (u *User) GetByID(ctx context.Context, userID string) (*domain.User, error) {
-	q := u.psql.Select("user_id", "username", "team_name", "is_active").
+	q := u.psql.Select("user_id", "username", "team_name", "is_active", "level", "timezone", "work_start", "work_end", "max_open_reviews").
 		From("users").
 		Where(sq.Eq{"user_id": userID})
 
@@ -85,7 +108,8 @@ func (u *User) GetByID(ctx context.Context, userID string) (*domain.User, error)
 
 	var user domain.User
 	err = u.pool.QueryRow(ctx, sql, args...).Scan(
-		&user.UserID, &user.Username, &user.TeamName, &user.IsActive,
+		&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Level,
+		&user.Timezone, &user.WorkStart, &user.WorkEnd, &user.MaxOpenReviews,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -98,7 +122,7 @@ func (u *User) GetByID(ctx context.Context, userID string) (*domain.User, error)
 }
 
 func (u *User) GetByTeamName(ctx context.Context, teamName string) ([]*domain.User, error) {
-	q := u.psql.Select("user_id", "username", "team_name", "is_active").
+	q := u.psql.Select("user_id", "username", "team_name", "is_active", "level", "timezone", "work_start", "work_end", "max_open_reviews").
 		From("users").
 		Where(sq.Eq{"team_name": teamName}).
 		OrderBy("user_id")
@@ -117,7 +141,10 @@ func (u *User) GetByTeamName(ctx context.Context, teamName string) ([]*domain.Us
 	var users []*domain.User
 	for rows.Next() {
 		var user domain.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+		if err := rows.Scan(
+			&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Level,
+			&user.Timezone, &user.WorkStart, &user.WorkEnd, &user.MaxOpenReviews,
+		); err != nil {
 			return nil, fmt.Errorf("error scanning user: %w", err)
 		}
 		users = append(users, &user)
@@ -134,7 +161,7 @@ func (u *User) SetIsActive(ctx context.Context, userID string, isActive bool) (*
 	q := u.psql.Update("users").
 		Set("is_active", isActive).
 		Where(sq.Eq{"user_id": userID}).
-		Suffix("RETURNING user_id, username, team_name, is_active")
+		Suffix("RETURNING user_id, username, team_name, is_active, level, timezone, work_start, work_end, max_open_reviews")
 	sql, args, err := q.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("error building query: %w", err)
@@ -142,7 +169,8 @@ func (u *User) SetIsActive(ctx context.Context, userID string, isActive bool) (*
 
 	var user domain.User
 	err = u.pool.QueryRow(ctx, sql, args...).Scan(
-		&user.UserID, &user.Username, &user.TeamName, &user.IsActive,
+		&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Level,
+		&user.Timezone, &user.WorkStart, &user.WorkEnd, &user.MaxOpenReviews,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -154,10 +182,54 @@ func (u *User) SetIsActive(ctx context.Context, userID string, isActive bool) (*
 	return &user, nil
 }
 
+func (u *User) GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	if len(userIDs) == 0 {
+		return []*domain.User{}, nil
+	}
+
+	q := u.psql.Select("user_id", "username", "team_name", "is_active", "level", "timezone", "work_start", "work_end", "max_open_reviews").
+		From("users").
+		Where(sq.Eq{"user_id": userIDs}).
+		OrderBy("user_id")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := u.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*domain.User{}
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(
+			&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Level,
+			&user.Timezone, &user.WorkStart, &user.WorkEnd, &user.MaxOpenReviews,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning user: %w", err)
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetActiveByTeamExcluding returns active members of teamName that are not
+// inside an absence window right now.
 func (u *User) GetActiveByTeamExcluding(ctx context.Context, teamName string, excludeUserIDs []string) ([]*domain.User, error) {
-	q := u.psql.Select("user_id", "username", "team_name", "is_active").
+	now := time.Now()
+	q := u.psql.Select("user_id", "username", "team_name", "is_active", "level", "timezone", "work_start", "work_end", "max_open_reviews").
 		From("users").
-		Where(sq.Eq{"team_name": teamName, "is_active": true})
+		Where(sq.Eq{"team_name": teamName, "is_active": true}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM user_absences a WHERE a.user_id = users.user_id AND a.starts_at <= ? AND a.ends_at > ?)", now, now))
 
 	if len(excludeUserIDs) > 0 {
 		q = q.Where(sq.NotEq{"user_id": excludeUserIDs})
@@ -179,7 +251,10 @@ func (u *User) GetActiveByTeamExcluding(ctx context.Context, teamName string, ex
 	var users []*domain.User
 	for rows.Next() {
 		var user domain.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+		if err := rows.Scan(
+			&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Level,
+			&user.Timezone, &user.WorkStart, &user.WorkEnd, &user.MaxOpenReviews,
+		); err != nil {
 			return nil, fmt.Errorf("error scanning user: %w", err)
 		}
 		users = append(users, &user)
@@ -192,6 +267,123 @@ func (u *User) GetActiveByTeamExcluding(ctx context.Context, teamName string, ex
 	return users, nil
 }
 
+func (u *User) GetAbsentIDs(ctx context.Context, userIDs []string, at time.Time) (map[string]bool, error) {
+	absent := make(map[string]bool)
+	if len(userIDs) == 0 {
+		return absent, nil
+	}
+
+	q := u.psql.Select("DISTINCT user_id").
+		From("user_absences").
+		Where(sq.Eq{"user_id": userIDs}).
+		Where(sq.LtOrEq{"starts_at": at}).
+		Where(sq.Gt{"ends_at": at})
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := u.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying absences: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("error scanning absence: %w", err)
+		}
+		absent[userID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating absences: %w", err)
+	}
+
+	return absent, nil
+}
+
+func (u *User) SetSkills(ctx context.Context, userID string, skills []string) error {
+	tx, err := u.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	deleteQ := u.psql.Delete("user_skills").
+		Where(sq.Eq{"user_id": userID})
+
+	deleteSql, deleteArgs, err := deleteQ.ToSql()
+	if err != nil {
+		return fmt.Errorf("error building delete query: %w", err)
+	}
+	_, err = tx.Exec(ctx, deleteSql, deleteArgs...)
+	if err != nil {
+		return fmt.Errorf("error removing skills: %w", err)
+	}
+
+	if len(skills) > 0 {
+		insertQ := u.psql.Insert("user_skills").
+			Columns("user_id", "skill")
+		for _, skill := range skills {
+			insertQ = insertQ.Values(userID, skill)
+		}
+		insertSql, insertArgs, err := insertQ.ToSql()
+		if err != nil {
+			return fmt.Errorf("error building insert query: %w", err)
+		}
+		_, err = tx.Exec(ctx, insertSql, insertArgs...)
+		if err != nil {
+			return fmt.Errorf("error adding skills: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (u *User) GetSkills(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	skills := make(map[string][]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return skills, nil
+	}
+
+	q := u.psql.Select("user_id", "skill").
+		From("user_skills").
+		Where(sq.Eq{"user_id": userIDs}).
+		OrderBy("user_id", "skill")
+
+	sql, args, err := q.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error building query: %w", err)
+	}
+
+	rows, err := u.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying skills: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID, skill string
+		if err := rows.Scan(&userID, &skill); err != nil {
+			return nil, fmt.Errorf("error scanning skill: %w", err)
+		}
+		skills[userID] = append(skills[userID], skill)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating skills: %w", err)
+	}
+
+	return skills, nil
+}
+
 func (u *User) Exists(ctx context.Context, userID string) (bool, error) {
 	q := u.psql.Select("1").
 		From("users").
@@ -3,33 +3,106 @@ package repo
 import (
 	"Avito/pkg/domain"
 	"context"
+	"time"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	Update(ctx context.Context, userID string, patch *domain.UserUpdate) (*domain.User, error)
 	GetByID(ctx context.Context, userID string) (*domain.User, error)
+	GetByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error)
 	GetByTeamName(ctx context.Context, teamName string) ([]*domain.User, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
 	GetActiveByTeamExcluding(ctx context.Context, teamName string, excludeUserIDs []string) ([]*domain.User, error)
+	GetAbsentIDs(ctx context.Context, userIDs []string, at time.Time) (map[string]bool, error)
+	SetSkills(ctx context.Context, userID string, skills []string) error
+	GetSkills(ctx context.Context, userIDs []string) (map[string][]string, error)
 	Exists(ctx context.Context, userID string) (bool, error)
 }
 
 type TeamRepository interface {
 	Create(ctx context.Context, team *domain.Team) error
 	GetByName(ctx context.Context, teamName string) (*domain.Team, error)
+	SetReviewerStrategy(ctx context.Context, teamName string, strategy domain.ReviewerStrategy) error
+	GetRoundRobinCursor(ctx context.Context, teamName string) (string, error)
+	SetRoundRobinCursor(ctx context.Context, teamName string, lastUserID string) error
+	SetFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error
+	GetFallbackTeams(ctx context.Context, teamName string) ([]string, error)
+	GetAllNames(ctx context.Context) ([]string, error)
 	Exists(ctx context.Context, teamName string) (bool, error)
 }
 
 type PullRequestRepository interface {
 	Create(ctx context.Context, pr *domain.PullRequest) error
+	CreateWithTrace(ctx context.Context, pr *domain.PullRequest, trace *domain.AssignmentTrace) error
 	GetByID(ctx context.Context, prID string) (*domain.PullRequest, error)
 	GetByIDs(ctx context.Context, prIDs []string) ([]*domain.PullRequest, error)
+	GetOpenUnderReviewed(ctx context.Context) ([]*domain.PullRequest, error)
+	GetOpenByAuthorTeam(ctx context.Context, teamName string) ([]*domain.PullRequest, error)
+	Update(ctx context.Context, prID string, patch *domain.PullRequestUpdate) error
 	AddReviewer(ctx context.Context, prID string, userID string) error
 	RemoveReviewer(ctx context.Context, prID string, userID string) error
 	ReplaceReviewer(ctx context.Context, prID string, oldUserID string, newUserID string) error
+	ApplyRedistribution(ctx context.Context, redistribution *domain.ReviewRedistribution) error
+	ApplyReviewerChange(ctx context.Context, change *domain.ReviewerChange) error
+	ApplyReviewerChanges(ctx context.Context, changes []*domain.ReviewerChange) error
 	SetMerged(ctx context.Context, prID string) error
+	SetClosed(ctx context.Context, prID string) error
+	SetReopened(ctx context.Context, prID string, redistribution *domain.ReviewRedistribution) error
+	MarkReady(ctx context.Context, prID string, reviewers []string, underReviewed bool, trace *domain.AssignmentTrace) error
+	SetForceMerged(ctx context.Context, override *domain.MergeOverride) error
+	GetMergeOverride(ctx context.Context, prID string) (*domain.MergeOverride, error)
 	GetReviewers(ctx context.Context, prID string) ([]string, error)
+	GetReviewersByPRs(ctx context.Context, prIDs []string) (map[string][]string, error)
+	GetLabels(ctx context.Context, prID string) ([]string, error)
 	GetPRIDsByReviewer(ctx context.Context, userID string) ([]string, error)
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
+	GetOpenAssignments(ctx context.Context, userIDs []string, maxAge time.Duration, unreviewedOnly bool) ([]*domain.ReviewAssignment, error)
+	GetLastReviewersByAuthor(ctx context.Context, authorID string, excludePRID string) ([]string, error)
 	Exists(ctx context.Context, prID string) (bool, error)
 }
+
+type AssignmentTraceRepository interface {
+	Create(ctx context.Context, trace *domain.AssignmentTrace) error
+	GetByPullRequestID(ctx context.Context, prID string) ([]*domain.AssignmentTrace, error)
+}
+
+type OwnershipRepository interface {
+	ReplaceAll(ctx context.Context, rules []*domain.OwnershipRule) error
+	GetAll(ctx context.Context) ([]*domain.OwnershipRule, error)
+}
+
+type TeamPolicyRepository interface {
+	Upsert(ctx context.Context, policy *domain.TeamPolicy) error
+	GetByTeamName(ctx context.Context, teamName string) (*domain.TeamPolicy, error)
+}
+
+type ReviewerRuleRepository interface {
+	Create(ctx context.Context, rule *domain.ReviewerRule) error
+	Delete(ctx context.Context, ruleID int64) error
+	List(ctx context.Context) ([]*domain.ReviewerRule, error)
+	GetForAuthor(ctx context.Context, authorID string) ([]*domain.ReviewerRule, error)
+}
+
+type ReassignmentRepository interface {
+	Create(ctx context.Context, reassignment *domain.Reassignment) error
+	ListRecent(ctx context.Context, limit int) ([]*domain.Reassignment, error)
+}
+
+type AbsenceRepository interface {
+	Create(ctx context.Context, absence *domain.Absence) error
+	Delete(ctx context.Context, absenceID int64) error
+	GetByUserID(ctx context.Context, userID string) ([]*domain.Absence, error)
+	GetStartedUnhandled(ctx context.Context, at time.Time) ([]*domain.Absence, error)
+	MarkHandled(ctx context.Context, absenceID int64, at time.Time) error
+}
+
+type ReviewDeclineRepository interface {
+	Create(ctx context.Context, decline *domain.ReviewDecline) error
+	GetCountsByUsers(ctx context.Context, userIDs []string) (map[string]map[domain.DeclineReason]int, error)
+}
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *domain.Review) error
+	GetByPullRequestID(ctx context.Context, prID string) ([]*domain.Review, error)
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/repo"
+	"context"
+	"log"
+	"time"
+)
+
+type Absences struct {
+	absenceRepo repo.AbsenceRepository
+	userRepo    repo.UserRepository
+	reviews     *PullRequest
+	interval    time.Duration
+}
+
+func NewAbsences(absenceRepo repo.AbsenceRepository, userRepo repo.UserRepository, reviews *PullRequest, interval time.Duration) *Absences {
+	return &Absences{
+		absenceRepo: absenceRepo,
+		userRepo:    userRepo,
+		reviews:     reviews,
+		interval:    interval,
+	}
+}
+
+func (a *Absences) AddAbsence(ctx context.Context, absence *domain.Absence) (*domain.Absence, error) {
+	if absence.StartsAt.IsZero() || absence.EndsAt.IsZero() {
+		return nil, domain.NewDomainError(domain.ErrInvalidAbsence, "startsAt and endsAt are required")
+	}
+	if !absence.EndsAt.After(absence.StartsAt) {
+		return nil, domain.NewDomainError(domain.ErrInvalidAbsence, "endsAt must be after startsAt")
+	}
+	if !absence.EndsAt.After(time.Now()) {
+		return nil, domain.NewDomainError(domain.ErrInvalidAbsence, "absence is already over")
+	}
+	exists, err := a.userRepo.Exists(ctx, absence.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.NewDomainError(domain.ErrNotFound, "user not found")
+	}
+
+	absence.CreatedAt = time.Now()
+	if err := a.absenceRepo.Create(ctx, absence); err != nil {
+		return nil, err
+	}
+	return absence, nil
+}
+
+func (a *Absences) DeleteAbsence(ctx context.Context, absenceID int64) error {
+	return a.absenceRepo.Delete(ctx, absenceID)
+}
+
+func (a *Absences) ListAbsences(ctx context.Context, userID string) ([]*domain.Absence, error) {
+	exists, err := a.userRepo.Exists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.NewDomainError(domain.ErrNotFound, "user not found")
+	}
+	return a.absenceRepo.GetByUserID(ctx, userID)
+}
+
+// Start hands off open reviews of users whose absence has begun, checking
+// every configured interval until ctx is done.
+func (a *Absences) Start(ctx context.Context) {
+	if a.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.HandleStartedAbsences(ctx); err != nil {
+				log.Printf("failed to handle started absences: %v", err)
+			}
+		}
+	}
+}
+
+// HandleStartedAbsences hands off the open reviews of every user whose
+// absence has started and not been handled yet.
+func (a *Absences) HandleStartedAbsences(ctx context.Context) error {
+	now := time.Now()
+	absences, err := a.absenceRepo.GetStartedUnhandled(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, absence := range absences {
+		handoffs, err := a.reviews.HandOffReviews(ctx, absence.UserID)
+		if err != nil {
+			return err
+		}
+		if err := a.absenceRepo.MarkHandled(ctx, absence.ID, now); err != nil {
+			return err
+		}
+		if len(handoffs) > 0 {
+			log.Printf("handed off %d reviews of absent user %s", len(handoffs), absence.UserID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// approvalStatus checks the states of pr's assigned reviewers against the
+// approvals required by the author's team policy. COMMENTED verdicts neither
+// approve nor withdraw an approval or a change request.
+func (p *PullRequest) approvalStatus(ctx context.Context, pr *domain.PullRequest) (*domain.ApprovalStatus, error) {
+	author, err := p.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := resolveTeamPolicy(ctx, p.policyRepo, p.defaultPolicy, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	reviewers, err := p.prRepo.GetReviewers(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := p.reviewRepo.GetByPullRequestID(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.ApprovalStatus{
+		RequiredApprovals:  policy.RequiredApprovals,
+		ApprovedBy:         []string{},
+		PendingReviewers:   []string{},
+		ChangesRequestedBy: []string{},
+	}
+	for _, state := range reviewerStates(reviewers, history) {
+		switch domain.ReviewVerdict(state.State) {
+		case domain.VerdictApproved:
+			status.ApprovedBy = append(status.ApprovedBy, state.UserID)
+		case domain.VerdictChangesRequested:
+			status.ChangesRequestedBy = append(status.ChangesRequestedBy, state.UserID)
+		default:
+			status.PendingReviewers = append(status.PendingReviewers, state.UserID)
+		}
+	}
+	if missing := status.RequiredApprovals - len(status.ApprovedBy); missing > 0 {
+		status.MissingApprovals = missing
+	}
+	return status, nil
+}
+
+func notApprovedError(status *domain.ApprovalStatus) *domain.DomainError {
+	var problems []string
+	if status.MissingApprovals > 0 {
+		problems = append(problems, fmt.Sprintf("%d of %d required approvals missing", status.MissingApprovals, status.RequiredApprovals))
+	}
+	if len(status.ChangesRequestedBy) > 0 {
+		problems = append(problems, "changes requested by "+strings.Join(status.ChangesRequestedBy, ", "))
+	}
+	return &domain.DomainError{
+		Code:    domain.ErrNotApproved,
+		Message: strings.Join(problems, "; "),
+		Details: status,
+	}
+}
+
+// findMergeOverride returns the override prID was force-merged with, or
+// nil when it went through the approval gate.
+func (p *PullRequest) findMergeOverride(ctx context.Context, prID string) (*domain.MergeOverride, error) {
+	override, err := p.prRepo.GetMergeOverride(ctx, prID)
+	if err == nil {
+		return override, nil
+	}
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) && domainErr.Code == domain.ErrNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,450 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+type SeedFunc func() int64
+
+func TimeSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+type candidatePool struct {
+	source     domain.ReviewerSource
+	teamName   string
+	candidates []*domain.User
+	excluded   []*domain.ExcludedCandidate
+}
+
+type assignmentRequest struct {
+	prID             string
+	action           domain.AssignmentAction
+	authorTeam       string
+	pools            []*candidatePool
+	rules            *ruleEvaluator
+	labels           []string
+	requireSenior    bool
+	workingHours     domain.WorkingHoursMode
+	startWithinHours int
+	count            int
+	minimum          int
+	// preview leaves selector state such as round-robin cursors unsaved.
+	preview bool
+	// pending counts reviews the caller assigned but has not stored yet.
+	pending map[string]int
+}
+
+func (p *PullRequest) assignReviewers(ctx context.Context, req *assignmentRequest) ([]string, *domain.AssignmentTrace, error) {
+	strategy, selector, err := p.resolveSelector(ctx, req.authorTeam)
+	if err != nil {
+		return nil, nil, err
+	}
+	scorer, err := p.loadCandidateScorer(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	atCapacity, loads, err := p.loadAtCapacity(ctx, req.pools, req.pending)
+	if err != nil {
+		return nil, nil, err
+	}
+	state := newSelectionState(loads)
+	seed := p.seed()
+	rnd := rand.New(rand.NewSource(seed))
+	// With labels, one slot is left to a uniform random pick so that
+	// expertise does not concentrate on the same few reviewers.
+	reserveRandom := len(req.labels) > 0 && req.count > 1
+
+	trace := &domain.AssignmentTrace{
+		PullRequestID: req.prID,
+		Action:        req.action,
+		Strategy:      strategy,
+		Seed:          seed,
+		Labels:        append([]string{}, req.labels...),
+		Candidates:    []string{},
+		Pools:         []*domain.CandidatePool{},
+		Excluded:      []*domain.ExcludedCandidate{},
+		Selected:      []string{},
+		Violations:    []*domain.RuleViolation{},
+		Loads:         loads,
+		Cursors:       state.StartCursors,
+		CreatedAt:     time.Now(),
+	}
+	seenCandidates := make(map[string]bool)
+	seenExcluded := make(map[string]bool)
+	chosen := make(map[string]bool)
+	allowed := make([][]*domain.User, len(req.pools))
+	deferred := make([][]*domain.User, len(req.pools))
+	for i, pool := range req.pools {
+		var considered []*domain.User
+		for _, user := range pool.candidates {
+			var reason domain.ExclusionReason
+			switch {
+			case atCapacity[user.UserID]:
+				reason = domain.ExclusionAtCapacity
+			case req.workingHours == domain.WorkingHoursStrict && scorer.offHours[user.UserID]:
+				reason = domain.ExclusionOffHours
+			}
+			if reason != "" {
+				if !seenExcluded[user.UserID] {
+					seenExcluded[user.UserID] = true
+					trace.Excluded = append(trace.Excluded, &domain.ExcludedCandidate{UserID: user.UserID, Reason: reason})
+				}
+				continue
+			}
+			considered = append(considered, user)
+			if !seenCandidates[user.UserID] {
+				seenCandidates[user.UserID] = true
+				trace.Candidates = append(trace.Candidates, user.UserID)
+			}
+			if len(req.rules.violations(user.UserID)) > 0 {
+				deferred[i] = append(deferred[i], user)
+				continue
+			}
+			allowed[i] = append(allowed[i], user)
+		}
+		for _, excluded := range pool.excluded {
+			if !seenExcluded[excluded.UserID] {
+				seenExcluded[excluded.UserID] = true
+				trace.Excluded = append(trace.Excluded, excluded)
+			}
+		}
+		trace.Pools = append(trace.Pools, &domain.CandidatePool{
+			Source:     pool.source,
+			TeamName:   pool.teamName,
+			Candidates: userIDs(considered, len(considered)),
+			Selected:   []string{},
+		})
+	}
+	record := func(i int, selected []string, violating bool) {
+		for _, userID := range selected {
+			chosen[userID] = true
+			if violating {
+				trace.Violations = append(trace.Violations, req.rules.violations(userID)...)
+			}
+		}
+		trace.Pools[i].Selected = append(trace.Pools[i].Selected, selected...)
+		trace.Selected = append(trace.Selected, selected...)
+	}
+
+	if req.requireSenior && req.count > 0 {
+		for _, candidates := range [][][]*domain.User{allowed, deferred} {
+			for i, users := range candidates {
+				seniors := unchosen(users, chosen, func(user *domain.User) bool { return user.Level.IsSenior() })
+				if len(seniors) == 0 {
+					continue
+				}
+				selected, err := p.selectByScore(ctx, selector, state, poolCursorTeam(req.pools[i], req.authorTeam), seniors, 1, rnd, scorer)
+				if err != nil {
+					return nil, nil, err
+				}
+				trace.SeniorPick = selected[0]
+				record(i, selected, len(req.rules.violations(selected[0])) > 0)
+				break
+			}
+			if trace.SeniorPick != "" {
+				break
+			}
+		}
+	}
+
+	for i, pool := range req.pools {
+		remaining := req.count - len(trace.Selected)
+		available := unchosen(allowed[i], chosen, nil)
+		if remaining <= 0 || len(available) == 0 {
+			continue
+		}
+		scored := remaining
+		if reserveRandom {
+			scored--
+		}
+		selected, err := p.selectByScore(ctx, selector, state, poolCursorTeam(pool, req.authorTeam), available, scored, rnd, scorer)
+		if err != nil {
+			return nil, nil, err
+		}
+		if reserveRandom {
+			if pick := randomPick(available, selected, rnd, scorer); pick != "" {
+				selected = append(selected, pick)
+				trace.RandomPick = pick
+				reserveRandom = false
+			}
+		}
+		record(i, selected, false)
+	}
+
+	for i, users := range deferred {
+		remaining := req.minimum - len(trace.Selected)
+		if remaining <= 0 {
+			break
+		}
+		available := unchosen(users, chosen, nil)
+		if len(available) == 0 {
+			continue
+		}
+		selected, err := selector.Select(ctx, state, poolCursorTeam(req.pools[i], req.authorTeam), available, remaining, rnd)
+		if err != nil {
+			return nil, nil, err
+		}
+		record(i, selected, true)
+	}
+	for _, users := range deferred {
+		for _, user := range users {
+			if !chosen[user.UserID] && !seenExcluded[user.UserID] {
+				seenExcluded[user.UserID] = true
+				trace.Excluded = append(trace.Excluded, &domain.ExcludedCandidate{UserID: user.UserID, Reason: domain.ExclusionRuleViolation})
+			}
+		}
+	}
+
+	if !req.preview {
+		for teamName, cursor := range state.Cursors {
+			if cursor == state.StartCursors[teamName] {
+				continue
+			}
+			if err := p.teamRepo.SetRoundRobinCursor(ctx, teamName, cursor); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return trace.Selected, trace, nil
+}
+
+func (p *PullRequest) selectByScore(ctx context.Context, selector ReviewerSelector, state *SelectionState, teamName string, candidates []*domain.User, count int, rnd *rand.Rand, scorer *candidateScorer) ([]string, error) {
+	tiers := make(map[candidateScore][]*domain.User)
+	var scores []candidateScore
+	for _, user := range candidates {
+		score := scorer.score(user)
+		if _, ok := tiers[score]; !ok {
+			scores = append(scores, score)
+		}
+		tiers[score] = append(tiers[score], user)
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].greater(scores[j]) })
+
+	selected := []string{}
+	for _, score := range scores {
+		remaining := count - len(selected)
+		if remaining <= 0 {
+			break
+		}
+		picked, err := selector.Select(ctx, state, teamName, tiers[score], remaining, rnd)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, picked...)
+	}
+	return selected, nil
+}
+
+func unchosen(users []*domain.User, chosen map[string]bool, keep func(*domain.User) bool) []*domain.User {
+	var available []*domain.User
+	for _, user := range users {
+		if !chosen[user.UserID] && (keep == nil || keep(user)) {
+			available = append(available, user)
+		}
+	}
+	return available
+}
+
+func randomPick(candidates []*domain.User, selected []string, rnd *rand.Rand, scorer *candidateScorer) string {
+	taken := make(map[string]bool, len(selected))
+	for _, userID := range selected {
+		taken[userID] = true
+	}
+	var rest, online []string
+	for _, user := range candidates {
+		if taken[user.UserID] {
+			continue
+		}
+		rest = append(rest, user.UserID)
+		if !scorer.offHours[user.UserID] {
+			online = append(online, user.UserID)
+		}
+	}
+	if len(online) > 0 {
+		rest = online
+	}
+	if len(rest) == 0 {
+		return ""
+	}
+	return rest[rnd.Intn(len(rest))]
+}
+
+func poolCursorTeam(pool *candidatePool, authorTeam string) string {
+	if pool.teamName == "" {
+		return authorTeam
+	}
+	return pool.teamName
+}
+
+func (p *PullRequest) collectCandidates(ctx context.Context, teamName string, excluded map[string]domain.ExclusionReason) (*candidatePool, error) {
+	members, err := p.userRepo.GetByTeamName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	excludeIDs := make([]string, 0, len(excluded))
+	for userID := range excluded {
+		excludeIDs = append(excludeIDs, userID)
+	}
+	candidates, err := p.userRepo.GetActiveByTeamExcluding(ctx, teamName, excludeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make(map[string]bool, len(candidates))
+	for _, user := range candidates {
+		available[user.UserID] = true
+	}
+	pool := &candidatePool{
+		source:     domain.ReviewerSourceTeam,
+		teamName:   teamName,
+		candidates: candidates,
+		excluded:   []*domain.ExcludedCandidate{},
+	}
+	for _, member := range members {
+		if reason, ok := excluded[member.UserID]; ok {
+			pool.excluded = append(pool.excluded, &domain.ExcludedCandidate{UserID: member.UserID, Reason: reason})
+			continue
+		}
+		if !member.IsActive {
+			pool.excluded = append(pool.excluded, &domain.ExcludedCandidate{UserID: member.UserID, Reason: domain.ExclusionInactive})
+		} else if !available[member.UserID] {
+			pool.excluded = append(pool.excluded, &domain.ExcludedCandidate{UserID: member.UserID, Reason: domain.ExclusionAbsent})
+		}
+	}
+
+	return pool, nil
+}
+
+func (p *PullRequest) collectOwnerCandidates(ctx context.Context, paths []string, excluded map[string]domain.ExclusionReason) (*candidatePool, error) {
+	rules, err := p.ownershipRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pool := &candidatePool{
+		source:   domain.ReviewerSourceCodeOwners,
+		excluded: []*domain.ExcludedCandidate{},
+	}
+	teamPools := make(map[string]*candidatePool)
+	teamPool := func(teamName string) (*candidatePool, error) {
+		if cached, ok := teamPools[teamName]; ok {
+			return cached, nil
+		}
+		collected, err := p.collectCandidates(ctx, teamName, excluded)
+		if err != nil {
+			return nil, err
+		}
+		teamPools[teamName] = collected
+		return collected, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, owner := range ownersForPaths(rules, paths) {
+		switch owner.Type {
+		case domain.OwnerTypeTeam:
+			collected, err := teamPool(owner.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, user := range collected.candidates {
+				if !seen[user.UserID] {
+					seen[user.UserID] = true
+					pool.candidates = append(pool.candidates, user)
+				}
+			}
+			for _, excludedUser := range collected.excluded {
+				if !seen[excludedUser.UserID] {
+					seen[excludedUser.UserID] = true
+					pool.excluded = append(pool.excluded, excludedUser)
+				}
+			}
+		case domain.OwnerTypeUser:
+			if seen[owner.ID] {
+				continue
+			}
+			user, err := p.userRepo.GetByID(ctx, owner.ID)
+			if err != nil {
+				var domainErr *domain.DomainError
+				if errors.As(err, &domainErr) && domainErr.Code == domain.ErrNotFound {
+					continue
+				}
+				return nil, err
+			}
+			collected, err := teamPool(user.TeamName)
+			if err != nil {
+				return nil, err
+			}
+			seen[owner.ID] = true
+			for _, candidate := range collected.candidates {
+				if candidate.UserID == owner.ID {
+					pool.candidates = append(pool.candidates, candidate)
+				}
+			}
+			for _, excludedUser := range collected.excluded {
+				if excludedUser.UserID == owner.ID {
+					pool.excluded = append(pool.excluded, excludedUser)
+				}
+			}
+		}
+	}
+
+	return pool, nil
+}
+
+func (p *PullRequest) collectFallbackCandidates(ctx context.Context, teamName string, skipTeams map[string]bool, excluded map[string]domain.ExclusionReason) ([]*candidatePool, error) {
+	fallbackTeams, err := p.teamRepo.GetFallbackTeams(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	var pools []*candidatePool
+	for _, fallbackTeam := range fallbackTeams {
+		if skipTeams[fallbackTeam] {
+			continue
+		}
+		pool, err := p.collectCandidates(ctx, fallbackTeam, excluded)
+		if err != nil {
+			return nil, err
+		}
+		pool.source = domain.ReviewerSourceFallback
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+func filterByTeam(users []*domain.User, teamName string) []*domain.User {
+	var filtered []*domain.User
+	for _, user := range users {
+		if user.TeamName == teamName {
+			filtered = append(filtered, user)
+		}
+	}
+	return filtered
+}
+
+func selectedFromSource(trace *domain.AssignmentTrace, source domain.ReviewerSource) []string {
+	var selected []string
+	for _, pool := range trace.Pools {
+		if pool.Source == source {
+			selected = append(selected, pool.Selected...)
+		}
+	}
+	return selected
+}
+
+func (p *PullRequest) resolveSelector(ctx context.Context, teamName string) (domain.ReviewerStrategy, ReviewerSelector, error) {
+	team, err := p.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return "", nil, err
+	}
+	selector, ok := p.selectors[team.ReviewerStrategy]
+	if !ok {
+		return domain.StrategyLeastLoaded, p.selectors[domain.StrategyLeastLoaded], nil
+	}
+	return team.ReviewerStrategy, selector, nil
+}
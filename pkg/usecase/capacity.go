@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+)
+
+// loadAtCapacity returns the pool candidates that reached their review limit
+// along with the open-review count of every candidate, pending reviews
+// included.
+func (p *PullRequest) loadAtCapacity(ctx context.Context, pools []*candidatePool, pending map[string]int) (map[string]bool, map[string]int, error) {
+	users := make(map[string]*domain.User)
+	var ids []string
+	for _, pool := range pools {
+		for _, user := range pool.candidates {
+			if _, ok := users[user.UserID]; !ok {
+				users[user.UserID] = user
+				ids = append(ids, user.UserID)
+			}
+		}
+	}
+	atCapacity := make(map[string]bool)
+	if len(ids) == 0 {
+		return atCapacity, map[string]int{}, nil
+	}
+	counts, err := p.prRepo.GetOpenReviewCounts(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policies := make(map[string]*domain.TeamPolicy)
+	for _, userID := range ids {
+		// Users without open reviews are missing from counts; storing the
+		// sum keeps every candidate's load in the trace, zeros included.
+		counts[userID] += pending[userID]
+		user := users[userID]
+		policy, ok := policies[user.TeamName]
+		if !ok {
+			policy, err = resolveTeamPolicy(ctx, p.policyRepo, p.defaultPolicy, user.TeamName)
+			if err != nil {
+				return nil, nil, err
+			}
+			policies[user.TeamName] = policy
+		}
+		if atReviewLimit(policy, user, counts[userID]) {
+			atCapacity[userID] = true
+		}
+	}
+	return atCapacity, counts, nil
+}
+
+// atReviewLimit reports whether openReviews reaches the user's own limit,
+// falling back to the team policy one; zero means unlimited.
+func atReviewLimit(policy *domain.TeamPolicy, user *domain.User, openReviews int) bool {
+	limit := policy.MaxOpenReviews
+	if user.MaxOpenReviews != nil {
+		limit = *user.MaxOpenReviews
+	}
+	return limit > 0 && openReviews >= limit
+}
+
+func capacityLimited(trace *domain.AssignmentTrace) bool {
+	for _, excluded := range trace.Excluded {
+		if excluded.Reason == domain.ExclusionAtCapacity {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"log"
+	"time"
+)
+
+// ClosePullRequest abandons an open or draft PR. Its reviewers keep the assignment
+// but it no longer counts towards their load, so the review queue is
+// processed afterwards.
+func (p *PullRequest) ClosePullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pr, err := p.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	switch pr.Status {
+	case domain.PRStatusMerged:
+		return nil, domain.NewDomainError(domain.ErrPRMerged, "cannot close merged PR")
+	case domain.PRStatusOpen, domain.PRStatusDraft:
+		if err := p.prRepo.SetClosed(ctx, prID); err != nil {
+			return nil, err
+		}
+		if _, err := p.releaseReviewers(ctx, prID); err != nil {
+			log.Printf("failed to process review queue after closing %s: %v", prID, err)
+		}
+		pr, err = p.prRepo.GetByID(ctx, prID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	reviewers, err := p.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	pr.AssignedReviewers = reviewers
+	if err := p.attachReviewerStates(ctx, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// ReopenPullRequest puts a closed PR back in review. A PR closed as a draft
+// goes back to DRAFT and still needs MarkReady. Otherwise reviewers who
+// became inactive or are absent are handed off the way RedistributeReviews
+// does, in the same transaction as the reopen, and the PR is topped up if it
+// ends up under-reviewed.
+func (p *PullRequest) ReopenPullRequest(ctx context.Context, prID string) (*domain.PullRequest, []*domain.ReviewHandoff, error) {
+	pr, err := p.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	handoffs := []*domain.ReviewHandoff{}
+	switch pr.Status {
+	case domain.PRStatusMerged:
+		return nil, nil, domain.NewDomainError(domain.ErrPRMerged, "cannot reopen merged PR")
+	case domain.PRStatusClosed:
+		// A PR closed as a draft has no reviewers, so nothing is handed off.
+		departing, err := p.unavailableReviewers(ctx, prID)
+		if err != nil {
+			return nil, nil, err
+		}
+		assignments := make([]*domain.ReviewAssignment, 0, len(departing))
+		for _, userID := range departing {
+			assignments = append(assignments, &domain.ReviewAssignment{PullRequestID: prID, AuthorID: pr.AuthorID, UserID: userID})
+		}
+		report, redistribution, err := p.planRedistribution(ctx, assignments, departing)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := p.prRepo.SetReopened(ctx, prID, redistribution); err != nil {
+			return nil, nil, err
+		}
+		for _, entry := range report {
+			handoffs = append(handoffs, entry.Handoffs...)
+		}
+		pr, err = p.prRepo.GetByID(ctx, prID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if pr.Status == domain.PRStatusOpen && pr.UnderReviewed {
+			if _, err := p.topUpReviewers(ctx, pr); err != nil {
+				log.Printf("failed to top up reopened PR %s: %v", prID, err)
+			}
+		}
+	}
+	reviewers, err := p.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	pr.AssignedReviewers = reviewers
+	if err := p.attachReviewerStates(ctx, pr); err != nil {
+		return nil, nil, err
+	}
+	return pr, handoffs, nil
+}
+
+// unavailableReviewers returns the reviewers of prID who are inactive or
+// absent right now.
+func (p *PullRequest) unavailableReviewers(ctx context.Context, prID string) ([]string, error) {
+	reviewerIDs, err := p.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	reviewers, err := p.userRepo.GetByIDs(ctx, reviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+	absent, err := p.userRepo.GetAbsentIDs(ctx, reviewerIDs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	var unavailable []string
+	for _, reviewer := range reviewers {
+		if !reviewer.IsActive || absent[reviewer.UserID] {
+			unavailable = append(unavailable, reviewer.UserID)
+		}
+	}
+	return unavailable, nil
+}
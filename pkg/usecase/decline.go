@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"log"
+	"time"
+)
+
+// DeclineReview lets an assigned reviewer turn the PR down. The review goes
+// to a replacement the way ReassignReviewer picks one, or is dropped when
+// nobody can take it, and the decline is recorded for the reviewer's stats
+// in the same transaction as the reviewer change.
+func (p *PullRequest) DeclineReview(ctx context.Context, prID, reviewerID string, reason domain.DeclineReason, comment string) (*domain.PullRequest, *domain.ReviewDecline, error) {
+	if !reason.IsValid() {
+		return nil, nil, domain.NewDomainError(domain.ErrInvalidDecline, "unknown decline reason")
+	}
+	if err := p.checkNoVerdict(ctx, prID, reviewerID); err != nil {
+		return nil, nil, err
+	}
+
+	_, change, err := p.planHandOff(ctx, prID, reviewerID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	decline := &domain.ReviewDecline{
+		PullRequestID: prID,
+		UserID:        reviewerID,
+		Reason:        reason,
+		Comment:       comment,
+		CreatedAt:     time.Now(),
+	}
+	// A removal may top the PR up with someone else, who then takes over.
+	if len(change.Added) > 0 {
+		decline.ReplacedBy = change.Added[0]
+	}
+	change.Decline = decline
+	if err := p.prRepo.ApplyReviewerChange(ctx, change); err != nil {
+		return nil, nil, err
+	}
+	if _, err := p.releaseCapacity(ctx, change.Removed, prID); err != nil {
+		log.Printf("failed to process review queue after %s declined %s: %v", reviewerID, prID, err)
+	}
+
+	pr, err := p.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	reviewers, err := p.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	labels, err := p.prRepo.GetLabels(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	pr.Labels = labels
+	pr.AssignedReviewers = reviewers
+	if change.Trace != nil {
+		pr.FallbackReviewers = selectedFromSource(change.Trace, domain.ReviewerSourceFallback)
+		pr.RuleViolations = change.Trace.Violations
+	}
+	if err := p.attachReviewerStates(ctx, pr); err != nil {
+		return nil, nil, err
+	}
+	return pr, decline, nil
+}
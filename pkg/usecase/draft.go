@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"time"
+)
+
+func (p *PullRequest) createDraft(ctx context.Context, params *CreatePullRequestParams) (*domain.PullRequest, error) {
+	if _, err := p.userRepo.GetByID(ctx, params.AuthorID); err != nil {
+		return nil, err
+	}
+	pr := &domain.PullRequest{
+		PullRequestID:     params.PullRequestID,
+		PullRequestName:   params.PullRequestName,
+		AuthorID:          params.AuthorID,
+		Status:            domain.PRStatusDraft,
+		Labels:            normalizeTags(params.Labels),
+		AssignedReviewers: []string{},
+		CreatedAt:         time.Now(),
+	}
+	if err := p.prRepo.Create(ctx, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+type MarkReadyParams struct {
+	PullRequestID string
+	ChangedFiles  []string
+	QueueIfFull   bool
+}
+
+// MarkReady opens a draft PR and assigns reviewers exactly as
+// CreatePullRequest would. Labels are taken from the draft; changed files
+// are not stored with it and have to be passed again.
+func (p *PullRequest) MarkReady(ctx context.Context, params *MarkReadyParams) (*domain.PullRequest, error) {
+	pr, err := p.prRepo.GetByID(ctx, params.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	switch pr.Status {
+	case domain.PRStatusMerged:
+		return nil, domain.NewDomainError(domain.ErrPRMerged, "cannot mark merged PR ready")
+	case domain.PRStatusClosed:
+		return nil, domain.NewDomainError(domain.ErrPRClosed, "cannot mark closed PR ready")
+	case domain.PRStatusOpen:
+		reviewers, err := p.prRepo.GetReviewers(ctx, pr.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+		pr.AssignedReviewers = reviewers
+		if err := p.attachReviewerStates(ctx, pr); err != nil {
+			return nil, err
+		}
+		return pr, nil
+	}
+
+	labels, err := p.prRepo.GetLabels(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := p.planCreateAssignment(ctx, &CreatePullRequestParams{
+		PullRequestID: pr.PullRequestID,
+		AuthorID:      pr.AuthorID,
+		ChangedFiles:  params.ChangedFiles,
+		Labels:        labels,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+	reviewers, trace, policy := plan.reviewers, plan.trace, plan.policy
+	if len(reviewers) < policy.MinReviewers && capacityLimited(trace) && !params.QueueIfFull {
+		return nil, domain.NewDomainError(domain.ErrCapacityExhausted, "all candidate reviewers are at capacity")
+	}
+	trace.Action = domain.AssignmentActionReady
+
+	underReviewed := isUnderReviewed(policy, len(reviewers), trace.SeniorPick != "")
+	if err := p.prRepo.MarkReady(ctx, pr.PullRequestID, reviewers, underReviewed, trace); err != nil {
+		return nil, err
+	}
+
+	pr.Status = domain.PRStatusOpen
+	pr.Labels = plan.labels
+	pr.AssignedReviewers = reviewers
+	pr.FallbackReviewers = selectedFromSource(trace, domain.ReviewerSourceFallback)
+	pr.UnderReviewed = underReviewed
+	pr.RuleViolations = trace.Violations
+	pr.Reviewers = reviewerStates(reviewers, nil)
+	return pr, nil
+}
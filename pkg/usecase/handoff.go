@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"errors"
+)
+
+// HandOffReviews moves userID's open reviews to other candidates the way
+// ReassignReviewer would, and drops the assignment when nobody can take it.
+// All handoffs are planned first and written in one transaction, so a
+// failure leaves every review where it was.
+func (p *PullRequest) HandOffReviews(ctx context.Context, userID string) ([]*domain.ReviewHandoff, error) {
+	handoffs, changes, err := p.planHandOffs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.prRepo.ApplyReviewerChanges(ctx, changes); err != nil {
+		return nil, err
+	}
+	return handoffs, nil
+}
+
+// DeactivateReviewer deactivates userID and hands off their open reviews as
+// HandOffReviews does, in the same transaction.
+func (p *PullRequest) DeactivateReviewer(ctx context.Context, userID string) ([]*domain.ReviewHandoff, error) {
+	handoffs, changes, err := p.planHandOffs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	err = p.prRepo.ApplyRedistribution(ctx, &domain.ReviewRedistribution{
+		Deactivate: []string{userID},
+		Changes:    changes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handoffs, nil
+}
+
+func (p *PullRequest) planHandOffs(ctx context.Context, userID string) ([]*domain.ReviewHandoff, []*domain.ReviewerChange, error) {
+	prIDs, err := p.prRepo.GetPRIDsByReviewer(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	handoffs := []*domain.ReviewHandoff{}
+	if len(prIDs) == 0 {
+		return handoffs, nil, nil
+	}
+	prs, err := p.prRepo.GetByIDs(ctx, prIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	pending := make(map[string]int)
+	var changes []*domain.ReviewerChange
+	for _, pr := range prs {
+		if pr.Status != domain.PRStatusOpen {
+			continue
+		}
+		handoff, change, err := p.planHandOff(ctx, pr.PullRequestID, userID, pending)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, reviewerID := range change.Added {
+			pending[reviewerID]++
+		}
+		changes = append(changes, change)
+		handoffs = append(handoffs, handoff)
+	}
+	return handoffs, changes, nil
+}
+
+func (p *PullRequest) planHandOff(ctx context.Context, prID, userID string, pending map[string]int) (*domain.ReviewHandoff, *domain.ReviewerChange, error) {
+	change, _, err := p.planReassign(ctx, prID, userID, "", pending)
+	if err == nil {
+		return &domain.ReviewHandoff{
+			PullRequestID: prID,
+			FromUserID:    userID,
+			Outcome:       domain.HandoffReassigned,
+			ReplacedBy:    change.Added[0],
+		}, change, nil
+	}
+	var domainErr *domain.DomainError
+	if !errors.As(err, &domainErr) || (domainErr.Code != domain.ErrNoCandidate && domainErr.Code != domain.ErrCapacityExhausted) {
+		return nil, nil, err
+	}
+	change, err = p.planRemoval(ctx, prID, userID, pending)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &domain.ReviewHandoff{
+		PullRequestID: prID,
+		FromUserID:    userID,
+		Outcome:       domain.HandoffRemoved,
+	}, change, nil
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/repo"
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type Ownership struct {
+	ownershipRepo repo.OwnershipRepository
+}
+
+func NewOwnership(ownershipRepo repo.OwnershipRepository) *Ownership {
+	return &Ownership{
+		ownershipRepo: ownershipRepo,
+	}
+}
+
+func (o *Ownership) SetRules(ctx context.Context, rules []*domain.OwnershipRule) ([]*domain.OwnershipRule, error) {
+	for _, rule := range rules {
+		if err := validateOwnershipRule(rule); err != nil {
+			return nil, err
+		}
+	}
+	if err := o.ownershipRepo.ReplaceAll(ctx, rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (o *Ownership) ImportCodeOwners(ctx context.Context, content string) ([]*domain.OwnershipRule, error) {
+	rules, err := ParseCodeOwners(content)
+	if err != nil {
+		return nil, err
+	}
+	return o.SetRules(ctx, rules)
+}
+
+func (o *Ownership) GetRules(ctx context.Context) ([]*domain.OwnershipRule, error) {
+	return o.ownershipRepo.GetAll(ctx)
+}
+
+// ParseCodeOwners reads a CODEOWNERS file. "@org/team" and "@team/name" entries
+// resolve to the team after the slash, any other "@name" entry to a user id.
+func ParseCodeOwners(content string) ([]*domain.OwnershipRule, error) {
+	rules := []*domain.OwnershipRule{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		rule := &domain.OwnershipRule{Pattern: fields[0], Owners: []*domain.CodeOwner{}}
+		for _, field := range fields[1:] {
+			if strings.HasPrefix(field, "#") {
+				break
+			}
+			if !strings.HasPrefix(field, "@") || len(field) == 1 {
+				return nil, domain.NewDomainError(domain.ErrInvalidOwnership, fmt.Sprintf("line %d: unsupported owner %q", lineNo, field))
+			}
+			name := strings.TrimPrefix(field, "@")
+			if idx := strings.LastIndex(name, "/"); idx >= 0 {
+				rule.Owners = append(rule.Owners, &domain.CodeOwner{Type: domain.OwnerTypeTeam, ID: name[idx+1:]})
+			} else {
+				rule.Owners = append(rule.Owners, &domain.CodeOwner{Type: domain.OwnerTypeUser, ID: name})
+			}
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read CODEOWNERS: %w", err)
+	}
+	return rules, nil
+}
+
+// ownersForPaths applies CODEOWNERS precedence: the last matching rule owns a path.
+func ownersForPaths(rules []*domain.OwnershipRule, paths []string) []*domain.CodeOwner {
+	seen := make(map[domain.CodeOwner]bool)
+	owners := []*domain.CodeOwner{}
+	for _, path := range paths {
+		path = strings.TrimPrefix(strings.TrimPrefix(path, "./"), "/")
+		for i := len(rules) - 1; i >= 0; i-- {
+			if !matchesPattern(rules[i].Pattern, path) {
+				continue
+			}
+			for _, owner := range rules[i].Owners {
+				if !seen[*owner] {
+					seen[*owner] = true
+					owners = append(owners, owner)
+				}
+			}
+			break
+		}
+	}
+	return owners
+}
+
+func matchesPattern(pattern, path string) bool {
+	re, err := globToRegexp(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(path)
+}
+
+// globToRegexp compiles a CODEOWNERS pattern into a regexp over whole paths.
+// As in CODEOWNERS, a pattern also matches everything below the directory it
+// names; a trailing "/" matches only below it, and a last segment ending in
+// a single "*" stops at that level.
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	directory := strings.HasSuffix(pattern, "/")
+	trimmed := strings.Trim(pattern, "/")
+	shallow := strings.HasSuffix(trimmed, "*") && !strings.HasSuffix(trimmed, "**")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(trimmed); i++ {
+		switch c := trimmed[i]; c {
+		case '*':
+			if i+1 < len(trimmed) && trimmed[i+1] == '*' {
+				if i+2 < len(trimmed) && trimmed[i+2] == '/' {
+					b.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					b.WriteString(".*")
+					i++
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	switch {
+	case directory:
+		b.WriteString("/.*")
+	case !shallow:
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+func validateOwnershipRule(rule *domain.OwnershipRule) error {
+	if strings.TrimSpace(rule.Pattern) == "" {
+		return domain.NewDomainError(domain.ErrInvalidOwnership, "ownership rule pattern is empty")
+	}
+	if _, err := globToRegexp(rule.Pattern); err != nil {
+		return domain.NewDomainError(domain.ErrInvalidOwnership, fmt.Sprintf("invalid pattern %q", rule.Pattern))
+	}
+	if rule.Owners == nil {
+		rule.Owners = []*domain.CodeOwner{}
+	}
+	for _, owner := range rule.Owners {
+		if owner.ID == "" || (owner.Type != domain.OwnerTypeUser && owner.Type != domain.OwnerTypeTeam) {
+			return domain.NewDomainError(domain.ErrInvalidOwnership, fmt.Sprintf("invalid owner in rule %q", rule.Pattern))
+		}
+	}
+	return nil
+}
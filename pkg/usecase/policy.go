@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/repo"
+	"context"
+	"errors"
+)
+
+func resolveTeamPolicy(ctx context.Context, policyRepo repo.TeamPolicyRepository, defaults domain.TeamPolicy, teamName string) (*domain.TeamPolicy, error) {
+	policy, err := policyRepo.GetByTeamName(ctx, teamName)
+	if err == nil {
+		return policy, nil
+	}
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) && domainErr.Code == domain.ErrNotFound {
+		policy := defaults
+		policy.TeamName = teamName
+		return &policy, nil
+	}
+	return nil, err
+}
+
+// reviewTarget is how many reviewers an open PR is kept at: the policy
+// minimum, but at least one when the team takes reviewers at all, so PRs of
+// teams without a minimum still wait in the queue when everyone is full.
+func reviewTarget(policy *domain.TeamPolicy) int {
+	if policy.MinReviewers == 0 && policy.MaxReviewers > 0 {
+		return 1
+	}
+	return policy.MinReviewers
+}
+
+func isUnderReviewed(policy *domain.TeamPolicy, reviewerCount int, hasSenior bool) bool {
+	return reviewerCount < reviewTarget(policy) || (policy.RequireSenior && !hasSenior)
+}
+
+func applyTeamPolicyUpdate(policy *domain.TeamPolicy, patch *domain.TeamPolicyUpdate) error {
+	if patch.MinReviewers != nil {
+		policy.MinReviewers = *patch.MinReviewers
+	}
+	if patch.MaxReviewers != nil {
+		policy.MaxReviewers = *patch.MaxReviewers
+	}
+	if patch.AllowCrossTeam != nil {
+		policy.AllowCrossTeam = *patch.AllowCrossTeam
+	}
+	if patch.RequireSenior != nil {
+		policy.RequireSenior = *patch.RequireSenior
+	}
+	if patch.WorkingHoursMode != nil {
+		policy.WorkingHoursMode = *patch.WorkingHoursMode
+	}
+	if patch.StartWithinHours != nil {
+		policy.StartWithinHours = *patch.StartWithinHours
+	}
+	if patch.MaxOpenReviews != nil {
+		policy.MaxOpenReviews = *patch.MaxOpenReviews
+	}
+	if patch.RequiredApprovals != nil {
+		policy.RequiredApprovals = *patch.RequiredApprovals
+	}
+
+	if policy.MinReviewers < 0 || policy.MaxReviewers < 0 {
+		return domain.NewDomainError(domain.ErrInvalidPolicy, "reviewer counts must not be negative")
+	}
+	if policy.MaxReviewers < policy.MinReviewers {
+		return domain.NewDomainError(domain.ErrInvalidPolicy, "max_reviewers must not be less than min_reviewers")
+	}
+	if !policy.WorkingHoursMode.IsValid() {
+		return domain.NewDomainError(domain.ErrInvalidPolicy, "unknown working_hours_mode")
+	}
+	if policy.StartWithinHours < 0 || policy.StartWithinHours > 24 {
+		return domain.NewDomainError(domain.ErrInvalidPolicy, "start_within_hours must be between 0 and 24")
+	}
+	if policy.MaxOpenReviews < 0 {
+		return domain.NewDomainError(domain.ErrInvalidPolicy, "max_open_reviews must not be negative")
+	}
+	// Only min_reviewers are guaranteed, so requiring more approvals would
+	// leave PRs that got the minimum unable to merge.
+	if policy.RequiredApprovals < 0 || policy.RequiredApprovals > policy.MinReviewers {
+		return domain.NewDomainError(domain.ErrInvalidPolicy, "required_approvals must be between 0 and min_reviewers")
+	}
+	return nil
+}
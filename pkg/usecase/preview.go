@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"fmt"
+)
+
+// PreviewAssignment runs the CreatePullRequest selection for params without
+// persisting the PR, its trace or the round-robin cursor. The PR id is
+// optional and only used to evaluate reviewer rules.
+func (p *PullRequest) PreviewAssignment(ctx context.Context, params *CreatePullRequestParams) (*domain.AssignmentPreview, error) {
+	plan, err := p.planCreateAssignment(ctx, params, true)
+	if err != nil {
+		return nil, err
+	}
+	reviewers, trace, policy := plan.reviewers, plan.trace, plan.policy
+
+	preview := &domain.AssignmentPreview{
+		AuthorID:          plan.author.UserID,
+		Strategy:          trace.Strategy,
+		Labels:            plan.labels,
+		ProposedReviewers: reviewers,
+		FallbackReviewers: selectedFromSource(trace, domain.ReviewerSourceFallback),
+		UnderReviewed:     isUnderReviewed(policy, len(reviewers), trace.SeniorPick != ""),
+		Candidates:        previewCandidates(trace),
+		RuleViolations:    trace.Violations,
+		Warnings:          []*domain.AssignmentWarning{},
+	}
+	warn := func(code domain.AssignmentWarningCode, format string, args ...any) {
+		preview.Warnings = append(preview.Warnings, &domain.AssignmentWarning{Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+	if len(reviewers) < policy.MinReviewers {
+		if capacityLimited(trace) {
+			warn(domain.WarningCapacityExhausted, "candidates are at capacity; creating the PR fails unless queue_if_full is set")
+		}
+		warn(domain.WarningUnderstaffed, "%d of %d required reviewers available", len(reviewers), policy.MinReviewers)
+	}
+	if policy.RequireSenior && trace.SeniorPick == "" {
+		warn(domain.WarningNoSenior, "no senior reviewer available")
+	}
+	if len(trace.Violations) > 0 {
+		warn(domain.WarningRuleViolation, "reviewer rules are broken to reach the minimum")
+	}
+	if len(preview.FallbackReviewers) > 0 {
+		warn(domain.WarningFallbackReviewers, "reviewers are taken from fallback teams")
+	}
+	return preview, nil
+}
+
+// previewCandidates flattens the trace into one entry per user: eligible
+// candidates with the pool they came from, then excluded users with the
+// reason.
+func previewCandidates(trace *domain.AssignmentTrace) []*domain.PreviewCandidate {
+	selected := make(map[string]bool, len(trace.Selected))
+	for _, userID := range trace.Selected {
+		selected[userID] = true
+	}
+	candidates := []*domain.PreviewCandidate{}
+	seen := make(map[string]bool)
+	for _, pool := range trace.Pools {
+		for _, userID := range pool.Candidates {
+			if seen[userID] {
+				continue
+			}
+			seen[userID] = true
+			candidates = append(candidates, &domain.PreviewCandidate{
+				UserID:   userID,
+				Source:   pool.Source,
+				TeamName: pool.TeamName,
+				Eligible: true,
+				Selected: selected[userID],
+			})
+		}
+	}
+	for _, excluded := range trace.Excluded {
+		if seen[excluded.UserID] {
+			continue
+		}
+		seen[excluded.UserID] = true
+		candidates = append(candidates, &domain.PreviewCandidate{
+			UserID: excluded.UserID,
+			Reason: excluded.Reason,
+		})
+	}
+	return candidates
+}
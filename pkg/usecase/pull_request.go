@@ -4,25 +4,65 @@ import (
 	"Avito/pkg/domain"
 	"Avito/pkg/repo"
 	"context"
-	"math/rand"
+	"crypto/subtle"
+	"errors"
+	"log"
+	"strings"
 	"time"
 )
 
 type PullRequest struct {
-	prRepo            repo.PullRequestRepository
-	userRepo          repo.UserRepository
-	maxCountReviewers int
+	prRepo        repo.PullRequestRepository
+	userRepo      repo.UserRepository
+	teamRepo      repo.TeamRepository
+	traceRepo     repo.AssignmentTraceRepository
+	ownershipRepo repo.OwnershipRepository
+	policyRepo    repo.TeamPolicyRepository
+	ruleRepo      repo.ReviewerRuleRepository
+	reviewRepo    repo.ReviewRepository
+	selectors     map[domain.ReviewerStrategy]ReviewerSelector
+	seed          SeedFunc
+	defaultPolicy domain.TeamPolicy
+	adminTokens   map[string]string
 }
 
-func NewPullRequest(prRepo repo.PullRequestRepository, userRepo repo.UserRepository, maxCountReviewers int) *PullRequest {
+func NewPullRequest(prRepo repo.PullRequestRepository, userRepo repo.UserRepository, teamRepo repo.TeamRepository, traceRepo repo.AssignmentTraceRepository, ownershipRepo repo.OwnershipRepository, policyRepo repo.TeamPolicyRepository, ruleRepo repo.ReviewerRuleRepository, reviewRepo repo.ReviewRepository, seed SeedFunc, defaultPolicy domain.TeamPolicy, adminTokens map[string]string) *PullRequest {
 	return &PullRequest{
-		prRepo:            prRepo,
-		userRepo:          userRepo,
-		maxCountReviewers: maxCountReviewers,
+		prRepo:        prRepo,
+		userRepo:      userRepo,
+		teamRepo:      teamRepo,
+		traceRepo:     traceRepo,
+		ownershipRepo: ownershipRepo,
+		policyRepo:    policyRepo,
+		ruleRepo:      ruleRepo,
+		reviewRepo:    reviewRepo,
+		selectors: map[domain.ReviewerStrategy]ReviewerSelector{
+			domain.StrategyRandom:      NewRandomSelector(),
+			domain.StrategyRoundRobin:  NewRoundRobinSelector(teamRepo),
+			domain.StrategyLeastLoaded: NewLeastLoadedSelector(),
+			domain.StrategyWeighted:    NewWeightedSelector(),
+		},
+		seed:          seed,
+		defaultPolicy: defaultPolicy,
+		adminTokens:   adminTokens,
 	}
 }
 
-func (p *PullRequest) CreatePullRequest(ctx context.Context, prID, prName, authorID string) (*domain.PullRequest, error) {
+type CreatePullRequestParams struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	ChangedFiles    []string
+	Labels          []string
+	// QueueIfFull creates the PR as under-reviewed instead of failing with
+	// CAPACITY_EXHAUSTED; it is topped up once reviewers free up.
+	QueueIfFull bool
+	// Draft stores the PR without reviewers until MarkReady is called.
+	Draft bool
+}
+
+func (p *PullRequest) CreatePullRequest(ctx context.Context, params *CreatePullRequestParams) (*domain.PullRequest, error) {
+	prID, authorID := params.PullRequestID, params.AuthorID
 	exists, err := p.prRepo.Exists(ctx, prID)
 	if err != nil {
 		return nil, err
@@ -30,76 +70,299 @@ func (p *PullRequest) CreatePullRequest(ctx context.Context, prID, prName, autho
 	if exists {
 		return nil, domain.NewDomainError(domain.ErrPRExists, "PR id already exists")
 	}
-
-	author, err := p.userRepo.GetByID(ctx, authorID)
-	if err != nil {
-		return nil, err
+	if params.Draft {
+		return p.createDraft(ctx, params)
 	}
 
-	candidates, err := p.userRepo.GetActiveByTeamExcluding(ctx, author.TeamName, []string{authorID})
+	plan, err := p.planCreateAssignment(ctx, params, false)
 	if err != nil {
 		return nil, err
 	}
-
-	reviewers := selectRandomReviewers(candidates, p.maxCountReviewers)
+	reviewers, trace, policy := plan.reviewers, plan.trace, plan.policy
+	if len(reviewers) < policy.MinReviewers && capacityLimited(trace) && !params.QueueIfFull {
+		return nil, domain.NewDomainError(domain.ErrCapacityExhausted, "all candidate reviewers are at capacity")
+	}
 
 	pr := &domain.PullRequest{
 		PullRequestID:     prID,
-		PullRequestName:   prName,
+		PullRequestName:   params.PullRequestName,
 		AuthorID:          authorID,
 		Status:            domain.PRStatusOpen,
+		Labels:            plan.labels,
 		AssignedReviewers: reviewers,
+		FallbackReviewers: selectedFromSource(trace, domain.ReviewerSourceFallback),
+		UnderReviewed:     isUnderReviewed(policy, len(reviewers), trace.SeniorPick != ""),
+		RuleViolations:    trace.Violations,
 		CreatedAt:         time.Now(),
 		MergedAt:          nil,
 	}
 
-	if err := p.prRepo.Create(ctx, pr); err != nil {
+	if err := p.prRepo.CreateWithTrace(ctx, pr, trace); err != nil {
 		return nil, err
 	}
+	pr.Reviewers = reviewerStates(pr.AssignedReviewers, nil)
 
 	return pr, nil
 }
 
-func (p *PullRequest) MergePullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
-	pr, err := p.prRepo.GetByID(ctx, prID)
+type createPlan struct {
+	author    *domain.User
+	policy    *domain.TeamPolicy
+	labels    []string
+	reviewers []string
+	trace     *domain.AssignmentTrace
+}
+
+// planCreateAssignment collects candidates and picks reviewers for a new PR
+// without writing anything except, outside preview, the round-robin cursor.
+func (p *PullRequest) planCreateAssignment(ctx context.Context, params *CreatePullRequestParams, preview bool) (*createPlan, error) {
+	prID, authorID := params.PullRequestID, params.AuthorID
+	author, err := p.userRepo.GetByID(ctx, authorID)
 	if err != nil {
 		return nil, err
 	}
-	if pr.Status == domain.PRStatusMerged {
-		reviewers, err := p.prRepo.GetReviewers(ctx, prID)
+
+	policy, err := resolveTeamPolicy(ctx, p.policyRepo, p.defaultPolicy, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := map[string]domain.ExclusionReason{authorID: domain.ExclusionAuthor}
+	var pools []*candidatePool
+	if len(params.ChangedFiles) > 0 {
+		ownerPool, err := p.collectOwnerCandidates(ctx, params.ChangedFiles, excluded)
 		if err != nil {
 			return nil, err
 		}
-		pr.AssignedReviewers = reviewers
-		return pr, nil
+		if !policy.AllowCrossTeam {
+			ownerPool.candidates = filterByTeam(ownerPool.candidates, author.TeamName)
+		}
+		pools = append(pools, ownerPool)
 	}
-	if err := p.prRepo.SetMerged(ctx, prID); err != nil {
+	teamPool, err := p.collectCandidates(ctx, author.TeamName, excluded)
+	if err != nil {
 		return nil, err
 	}
-	pr, err = p.prRepo.GetByID(ctx, prID)
+	pools = append(pools, teamPool)
+	if policy.AllowCrossTeam {
+		fallbackPools, err := p.collectFallbackCandidates(ctx, author.TeamName, map[string]bool{author.TeamName: true}, excluded)
+		if err != nil {
+			return nil, err
+		}
+		pools = append(pools, fallbackPools...)
+	}
+
+	rules, err := p.loadRuleEvaluator(ctx, author, prID)
 	if err != nil {
 		return nil, err
 	}
-	reviewers, err := p.prRepo.GetReviewers(ctx, prID)
+	labels := normalizeTags(params.Labels)
+
+	reviewers, trace, err := p.assignReviewers(ctx, &assignmentRequest{
+		prID:             prID,
+		action:           domain.AssignmentActionCreate,
+		authorTeam:       author.TeamName,
+		pools:            pools,
+		rules:            rules,
+		labels:           labels,
+		requireSenior:    policy.RequireSenior,
+		workingHours:     policy.WorkingHoursMode,
+		startWithinHours: policy.StartWithinHours,
+		count:            policy.MaxReviewers,
+		minimum:          policy.MinReviewers,
+		preview:          preview,
+	})
 	if err != nil {
 		return nil, err
 	}
+	return &createPlan{
+		author:    author,
+		policy:    policy,
+		labels:    labels,
+		reviewers: reviewers,
+		trace:     trace,
+	}, nil
+}
+
+type MergePullRequestParams struct {
+	PullRequestID string
+	// Force lets an admin merge a PR that lacks the required approvals.
+	// The admin is identified by AdminToken, never by a client-supplied
+	// user_id, and is recorded with OverrideReason on the override.
+	Force          bool
+	AdminToken     string
+	OverrideReason string
+}
+
+// MergePullRequest merges an open PR once enough assigned reviewers have
+// approved it and none still requests changes. The returned override is
+// set when the PR was force-merged past the approval gate.
+func (p *PullRequest) MergePullRequest(ctx context.Context, params *MergePullRequestParams) (*domain.PullRequest, *domain.MergeOverride, error) {
+	var mergedBy string
+	if params.Force {
+		if strings.TrimSpace(params.OverrideReason) == "" {
+			return nil, nil, domain.NewDomainError(domain.ErrInvalidOverride, "force merge requires override_reason")
+		}
+		var ok bool
+		mergedBy, ok = p.adminByToken(params.AdminToken)
+		if !ok {
+			return nil, nil, domain.NewDomainError(domain.ErrForbidden, "force merge requires a valid admin token")
+		}
+	}
+
+	prID := params.PullRequestID
+	pr, err := p.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pr.Status == domain.PRStatusClosed {
+		return nil, nil, domain.NewDomainError(domain.ErrPRClosed, "cannot merge closed PR")
+	}
+	if pr.Status == domain.PRStatusDraft {
+		return nil, nil, domain.NewDomainError(domain.ErrPRDraft, "cannot merge draft PR")
+	}
+
+	if pr.Status == domain.PRStatusOpen {
+		status, err := p.approvalStatus(ctx, pr)
+		if err != nil {
+			return nil, nil, err
+		}
+		var mergeErr error
+		switch {
+		case status.Satisfied():
+			mergeErr = p.prRepo.SetMerged(ctx, prID)
+		case params.Force:
+			override := &domain.MergeOverride{
+				PullRequestID:      prID,
+				UserID:             mergedBy,
+				Reason:             strings.TrimSpace(params.OverrideReason),
+				RequiredApprovals:  status.RequiredApprovals,
+				ApprovedBy:         status.ApprovedBy,
+				ChangesRequestedBy: status.ChangesRequestedBy,
+				CreatedAt:          time.Now(),
+			}
+			mergeErr = p.prRepo.SetForceMerged(ctx, override)
+		default:
+			return nil, nil, notApprovedError(status)
+		}
+		// The merge only applies while the PR is still open; losing the race
+		// to another merge keeps the call idempotent, any other change fails.
+		var domainErr *domain.DomainError
+		switch {
+		case mergeErr == nil:
+			if _, err := p.releaseReviewers(ctx, prID); err != nil {
+				log.Printf("failed to process review queue after merging %s: %v", prID, err)
+			}
+		case errors.As(mergeErr, &domainErr) && domainErr.Code == domain.ErrPRMerged:
+		default:
+			return nil, nil, mergeErr
+		}
+		pr, err = p.prRepo.GetByID(ctx, prID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	reviewers, err := p.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
 	pr.AssignedReviewers = reviewers
+	if err := p.attachReviewerStates(ctx, pr); err != nil {
+		return nil, nil, err
+	}
+	override, err := p.findMergeOverride(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return pr, nil
+	return pr, override, nil
 }
 
-func (p *PullRequest) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error) {
+// adminByToken returns the admin user_id configured for token. Tokens are
+// compared in constant time so a caller cannot guess one byte by byte.
+func (p *PullRequest) adminByToken(token string) (string, bool) {
+	if token == "" {
+		return "", false
+	}
+	for adminToken, userID := range p.adminTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+			return userID, true
+		}
+	}
+	return "", false
+}
+
+// ReassignReviewer replaces oldReviewerID on the PR. An empty newReviewerID
+// lets the team strategy pick the replacement. A reviewer who already
+// submitted a verdict has started the review and is not replaced, since the
+// verdict would stop counting.
+func (p *PullRequest) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (*domain.PullRequest, string, error) {
+	if err := p.checkNoVerdict(ctx, prID, oldReviewerID); err != nil {
+		return nil, "", err
+	}
+	change, labels, err := p.planReassign(ctx, prID, oldReviewerID, newReviewerID, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := p.prRepo.ApplyReviewerChange(ctx, change); err != nil {
+		return nil, "", err
+	}
+	if _, err := p.releaseCapacity(ctx, change.Removed, prID); err != nil {
+		log.Printf("failed to process review queue after reassigning %s on %s: %v", oldReviewerID, prID, err)
+	}
 	pr, err := p.prRepo.GetByID(ctx, prID)
 	if err != nil {
 		return nil, "", err
 	}
+	updatedReviewers, err := p.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, "", err
+	}
+	pr.Labels = labels
+	pr.AssignedReviewers = updatedReviewers
+	pr.FallbackReviewers = selectedFromSource(change.Trace, domain.ReviewerSourceFallback)
+	pr.RuleViolations = change.Trace.Violations
+	if err := p.attachReviewerStates(ctx, pr); err != nil {
+		return nil, "", err
+	}
+
+	return pr, change.Added[0], nil
+}
+
+// checkNoVerdict fails with REVIEW_SUBMITTED when userID already submitted
+// a verdict on prID, so their review cannot be handed to someone else.
+func (p *PullRequest) checkNoVerdict(ctx context.Context, prID, userID string) error {
+	history, err := p.reviewRepo.GetByPullRequestID(ctx, prID)
+	if err != nil {
+		return err
+	}
+	for _, review := range history {
+		if review.UserID == userID {
+			return domain.NewDomainError(domain.ErrReviewSubmitted, "reviewer already submitted a verdict on this PR")
+		}
+	}
+	return nil
+}
+
+// planReassign picks the replacement for oldReviewerID without writing
+// anything and returns the swap along with the PR labels. Reviews in
+// pending are planned by the caller but not stored yet and count towards
+// the candidates' load.
+func (p *PullRequest) planReassign(ctx context.Context, prID, oldReviewerID, newReviewerID string, pending map[string]int) (*domain.ReviewerChange, []string, error) {
+	pr, err := p.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
 	if pr.Status == domain.PRStatusMerged {
-		return nil, "", domain.NewDomainError(domain.ErrPRMerged, "cannot reassign on merged PR")
+		return nil, nil, domain.NewDomainError(domain.ErrPRMerged, "cannot reassign on merged PR")
+	}
+	if pr.Status == domain.PRStatusClosed {
+		return nil, nil, domain.NewDomainError(domain.ErrPRClosed, "cannot reassign on closed PR")
 	}
 	reviewers, err := p.prRepo.GetReviewers(ctx, prID)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	isAssigned := false
 	for _, reviewerID := range reviewers {
@@ -109,40 +372,289 @@ func (p *PullRequest) ReassignReviewer(ctx context.Context, prID, oldReviewerID
 		}
 	}
 	if !isAssigned {
-		return nil, "", domain.NewDomainError(domain.ErrNotAssigned, "reviewer is not assigned to this PR")
+		return nil, nil, domain.NewDomainError(domain.ErrNotAssigned, "reviewer is not assigned to this PR")
 	}
 	oldReviewer, err := p.userRepo.GetByID(ctx, oldReviewerID)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
-	author := pr.AuthorID
-	excludeIDs := append(reviewers, author)
-	candidates, err := p.userRepo.GetActiveByTeamExcluding(ctx, oldReviewer.TeamName, excludeIDs)
+	author, err := p.userRepo.GetByID(ctx, pr.AuthorID)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
+	}
+	policy, err := resolveTeamPolicy(ctx, p.policyRepo, p.defaultPolicy, author.TeamName)
+	if err != nil {
+		return nil, nil, err
+	}
+	rules, err := p.loadRuleEvaluator(ctx, author, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	labels, err := p.prRepo.GetLabels(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var keptReviewers []string
+	for _, reviewerID := range reviewers {
+		if reviewerID != oldReviewerID {
+			keptReviewers = append(keptReviewers, reviewerID)
+		}
+	}
+	keptSenior, err := p.hasSeniorReviewer(ctx, keptReviewers)
+	if err != nil {
+		return nil, nil, err
+	}
+	requireSenior := policy.RequireSenior && !keptSenior
+	if newReviewerID != "" {
+		trace, err := p.checkChosenReviewer(ctx, &chosenReviewerCheck{
+			prID:          prID,
+			action:        domain.AssignmentActionReassignChosen,
+			newReviewerID: newReviewerID,
+			author:        author,
+			oldReviewer:   oldReviewer,
+			reviewers:     reviewers,
+			policy:        policy,
+			rules:         rules,
+			labels:        labels,
+			requireSenior: requireSenior,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return reassignChange(pr, keptReviewers, oldReviewerID, newReviewerID, trace, policy, keptSenior), labels, nil
+	}
+
+	excluded := map[string]domain.ExclusionReason{author.UserID: domain.ExclusionAuthor}
+	for _, reviewerID := range reviewers {
+		excluded[reviewerID] = domain.ExclusionAlreadyAssigned
+	}
+	teamPool, err := p.collectCandidates(ctx, author.TeamName, excluded)
+	if err != nil {
+		return nil, nil, err
 	}
-	if len(candidates) == 0 {
-		return nil, "", domain.NewDomainError(domain.ErrNoCandidate, "no active replacement candidate in team")
+	pools := []*candidatePool{teamPool}
+	if policy.AllowCrossTeam {
+		fallbackPools, err := p.collectFallbackCandidates(ctx, author.TeamName, map[string]bool{author.TeamName: true}, excluded)
+		if err != nil {
+			return nil, nil, err
+		}
+		pools = append(pools, fallbackPools...)
+	}
+	newReviewers, trace, err := p.assignReviewers(ctx, &assignmentRequest{
+		prID:             prID,
+		action:           domain.AssignmentActionReassign,
+		authorTeam:       author.TeamName,
+		pools:            pools,
+		rules:            rules,
+		labels:           labels,
+		requireSenior:    requireSenior,
+		workingHours:     policy.WorkingHoursMode,
+		startWithinHours: policy.StartWithinHours,
+		count:            1,
+		minimum:          1,
+		pending:          pending,
+	})
+	if err != nil {
+		return nil, nil, err
 	}
-	newReviewers := selectRandomReviewers(candidates, 1)
 	if len(newReviewers) == 0 {
-		return nil, "", domain.NewDomainError(domain.ErrNoCandidate, "no active replacement candidate in team")
+		if capacityLimited(trace) {
+			return nil, nil, domain.NewDomainError(domain.ErrCapacityExhausted, "all replacement candidates are at capacity")
+		}
+		return nil, nil, domain.NewDomainError(domain.ErrNoCandidate, "no active replacement candidate in team or fallback teams")
 	}
-	newReviewerID := newReviewers[0]
-	if err := p.prRepo.ReplaceReviewer(ctx, prID, oldReviewerID, newReviewerID); err != nil {
-		return nil, "", err
+	if requireSenior && oldReviewer.Level.IsSenior() && trace.SeniorPick == "" {
+		return nil, nil, domain.NewDomainError(domain.ErrNoCandidate, "no senior replacement candidate in team or fallback teams")
 	}
-	pr, err = p.prRepo.GetByID(ctx, prID)
+	return reassignChange(pr, keptReviewers, oldReviewerID, newReviewers[0], trace, policy, keptSenior), labels, nil
+}
+
+// reassignChange swaps oldReviewerID for newReviewerID and records trace.
+func reassignChange(pr *domain.PullRequest, keptReviewers []string, oldReviewerID, newReviewerID string, trace *domain.AssignmentTrace, policy *domain.TeamPolicy, keptSenior bool) *domain.ReviewerChange {
+	change := &domain.ReviewerChange{
+		PullRequestID: pr.PullRequestID,
+		Removed:       []string{oldReviewerID},
+		Added:         []string{newReviewerID},
+		Trace:         trace,
+	}
+	underReviewed := isUnderReviewed(policy, len(keptReviewers)+1, keptSenior || trace.SeniorPick != "")
+	if pr.UnderReviewed != underReviewed {
+		change.UnderReviewed = &underReviewed
+	}
+	return change
+}
+
+// AddReviewer assigns an extra, hand-picked reviewer within the team
+// policy maximum.
+func (p *PullRequest) AddReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	pr, err := p.prRepo.GetByID(ctx, prID)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
-	updatedReviewers, err := p.prRepo.GetReviewers(ctx, prID)
+	if pr.Status == domain.PRStatusMerged {
+		return nil, domain.NewDomainError(domain.ErrPRMerged, "cannot add reviewer on merged PR")
+	}
+	if pr.Status == domain.PRStatusClosed {
+		return nil, domain.NewDomainError(domain.ErrPRClosed, "cannot add reviewer on closed PR")
+	}
+	if pr.Status == domain.PRStatusDraft {
+		return nil, domain.NewDomainError(domain.ErrPRDraft, "cannot add reviewer on draft PR")
+	}
+	reviewers, err := p.prRepo.GetReviewers(ctx, prID)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
-	pr.AssignedReviewers = updatedReviewers
+	author, err := p.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := resolveTeamPolicy(ctx, p.policyRepo, p.defaultPolicy, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviewers) >= policy.MaxReviewers {
+		return nil, domain.NewDomainError(domain.ErrReviewerLimit, "PR already has the maximum number of reviewers")
+	}
+	rules, err := p.loadRuleEvaluator(ctx, author, prID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := p.prRepo.GetLabels(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	hasSenior, err := p.hasSeniorReviewer(ctx, reviewers)
+	if err != nil {
+		return nil, err
+	}
+	trace, err := p.checkChosenReviewer(ctx, &chosenReviewerCheck{
+		prID:          prID,
+		action:        domain.AssignmentActionAddChosen,
+		newReviewerID: userID,
+		author:        author,
+		reviewers:     reviewers,
+		policy:        policy,
+		rules:         rules,
+		labels:        labels,
+		requireSenior: policy.RequireSenior && !hasSenior,
+	})
+	if err != nil {
+		return nil, err
+	}
+	change := &domain.ReviewerChange{PullRequestID: prID, Added: []string{userID}, Trace: trace}
+	reviewers = append(reviewers, userID)
+	underReviewed := isUnderReviewed(policy, len(reviewers), hasSenior || trace.SeniorPick != "")
+	if pr.UnderReviewed != underReviewed {
+		change.UnderReviewed = &underReviewed
+	}
+	if err := p.prRepo.ApplyReviewerChange(ctx, change); err != nil {
+		return nil, err
+	}
+	pr.UnderReviewed = underReviewed
+	pr.Labels = labels
+	pr.AssignedReviewers = reviewers
+	if err := p.attachReviewerStates(ctx, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// RemoveReviewer takes userID off the PR and, when that leaves it below its
+// review target, tops it up to the target in the same transaction.
+func (p *PullRequest) RemoveReviewer(ctx context.Context, prID, userID string) (*domain.PullRequest, error) {
+	change, err := p.planRemoval(ctx, prID, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.prRepo.ApplyReviewerChange(ctx, change); err != nil {
+		return nil, err
+	}
+	if _, err := p.releaseCapacity(ctx, change.Removed, prID); err != nil {
+		log.Printf("failed to process review queue after removing %s from %s: %v", userID, prID, err)
+	}
+	pr, err := p.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	reviewers, err := p.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := p.prRepo.GetLabels(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	pr.Labels = labels
+	pr.AssignedReviewers = reviewers
+	if err := p.attachReviewerStates(ctx, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
 
-	return pr, newReviewerID, nil
+// planRemoval returns the change that takes userID off prID and tops the PR
+// up to the review target, without writing anything. pending works as in
+// planReassign.
+func (p *PullRequest) planRemoval(ctx context.Context, prID, userID string, pending map[string]int) (*domain.ReviewerChange, error) {
+	pr, err := p.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status == domain.PRStatusMerged {
+		return nil, domain.NewDomainError(domain.ErrPRMerged, "cannot remove reviewer on merged PR")
+	}
+	if pr.Status == domain.PRStatusClosed {
+		return nil, domain.NewDomainError(domain.ErrPRClosed, "cannot remove reviewer on closed PR")
+	}
+	if pr.Status == domain.PRStatusDraft {
+		return nil, domain.NewDomainError(domain.ErrPRDraft, "cannot remove reviewer on draft PR")
+	}
+	reviewers, err := p.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	var kept []string
+	for _, reviewerID := range reviewers {
+		if reviewerID != userID {
+			kept = append(kept, reviewerID)
+		}
+	}
+	if len(kept) == len(reviewers) {
+		return nil, domain.NewDomainError(domain.ErrNotAssigned, "reviewer is not assigned to this PR")
+	}
+	author, err := p.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := resolveTeamPolicy(ctx, p.policyRepo, p.defaultPolicy, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	return p.planTopUp(ctx, pr, author, policy, kept, reviewTarget(policy), pending, userID)
+}
+
+func (p *PullRequest) hasSeniorReviewer(ctx context.Context, reviewerIDs []string) (bool, error) {
+	for _, reviewerID := range reviewerIDs {
+		reviewer, err := p.userRepo.GetByID(ctx, reviewerID)
+		if err != nil {
+			return false, err
+		}
+		if reviewer.Level.IsSenior() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p *PullRequest) GetAssignmentTraces(ctx context.Context, prID string) ([]*domain.AssignmentTrace, error) {
+	exists, err := p.prRepo.Exists(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.NewDomainError(domain.ErrNotFound, "pull request not found")
+	}
+	return p.traceRepo.GetByPullRequestID(ctx, prID)
 }
 
 func (p *PullRequest) GetUserReviews(ctx context.Context, userID string) ([]*domain.PullRequest, error) {
@@ -166,38 +678,22 @@ func (p *PullRequest) GetUserReviews(ctx context.Context, userID string) ([]*dom
 	if err != nil {
 		return nil, err
 	}
+	reviews := []*domain.PullRequest{}
 	for _, pr := range prs {
+		// Closed PRs are abandoned and no longer need a review.
+		if pr.Status == domain.PRStatusClosed {
+			continue
+		}
 		reviewers, err := p.prRepo.GetReviewers(ctx, pr.PullRequestID)
 		if err != nil {
 			return nil, err
 		}
 		pr.AssignedReviewers = reviewers
-	}
-
-	return prs, nil
-}
-
-func selectRandomReviewers(candidates []*domain.User, maxCount int) []string {
-	if len(candidates) == 0 {
-		return []string{}
-	}
-	if len(candidates) <= maxCount {
-		reviewers := make([]string, len(candidates))
-		for i, user := range candidates {
-			reviewers[i] = user.UserID
+		if err := p.attachReviewerStates(ctx, pr); err != nil {
+			return nil, err
 		}
-		return reviewers
-	}
-	rand.Seed(time.Now().UnixNano())
-	shuffled := make([]*domain.User, len(candidates))
-	copy(shuffled, candidates)
-	rand.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-	})
-	reviewers := make([]string, maxCount)
-	for i := 0; i < maxCount; i++ {
-		reviewers[i] = shuffled[i].UserID
+		reviews = append(reviews, pr)
 	}
 
-	return reviewers
+	return reviews, nil
 }
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"log"
+)
+
+// ProcessReviewQueue tops up open under-reviewed PRs, oldest first, that can
+// take reviewers from one of teams: PRs authored in such a team or in a team
+// that falls back to it. It returns the PRs that received new reviewers; a
+// PR that fails to top up is logged and skipped.
+func (p *PullRequest) ProcessReviewQueue(ctx context.Context, teams map[string]bool) ([]*domain.PullRequest, error) {
+	return p.processReviewQueue(ctx, teams, "")
+}
+
+// processReviewQueue is ProcessReviewQueue leaving skipPRID alone, so that a
+// reviewer just taken off that PR is not put straight back on it.
+func (p *PullRequest) processReviewQueue(ctx context.Context, teams map[string]bool, skipPRID string) ([]*domain.PullRequest, error) {
+	updated := []*domain.PullRequest{}
+	if len(teams) == 0 {
+		return updated, nil
+	}
+	prs, err := p.prRepo.GetOpenUnderReviewed(ctx)
+	if err != nil {
+		return nil, err
+	}
+	authorIDs := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		authorIDs = append(authorIDs, pr.AuthorID)
+	}
+	authors, err := p.userRepo.GetByIDs(ctx, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+	authorTeams := make(map[string]string, len(authors))
+	for _, author := range authors {
+		authorTeams[author.UserID] = author.TeamName
+	}
+
+	served := make(map[string]bool)
+	for _, pr := range prs {
+		if pr.PullRequestID == skipPRID {
+			continue
+		}
+		teamName := authorTeams[pr.AuthorID]
+		if _, ok := served[teamName]; !ok {
+			served[teamName], err = p.takesReviewersFrom(ctx, teamName, teams)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !served[teamName] {
+			continue
+		}
+		added, err := p.topUpReviewers(ctx, pr)
+		if err != nil {
+			log.Printf("failed to top up under-reviewed PR %s: %v", pr.PullRequestID, err)
+			continue
+		}
+		if len(added) > 0 {
+			updated = append(updated, pr)
+		}
+	}
+	return updated, nil
+}
+
+func (p *PullRequest) takesReviewersFrom(ctx context.Context, teamName string, teams map[string]bool) (bool, error) {
+	if teams[teamName] {
+		return true, nil
+	}
+	fallbackTeams, err := p.teamRepo.GetFallbackTeams(ctx, teamName)
+	if err != nil {
+		return false, err
+	}
+	for _, fallbackTeam := range fallbackTeams {
+		if teams[fallbackTeam] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// releaseReviewers processes the review queue once prID stopped counting
+// towards its reviewers' load.
+func (p *PullRequest) releaseReviewers(ctx context.Context, prID string) ([]*domain.PullRequest, error) {
+	reviewerIDs, err := p.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	return p.releaseCapacity(ctx, reviewerIDs, "")
+}
+
+// releaseCapacity processes the review queue once each of userIDs dropped
+// one open review, on prID when set. Only users who were at their limit free
+// a slot, so the queue is scoped to their teams and skipped without them.
+func (p *PullRequest) releaseCapacity(ctx context.Context, userIDs []string, prID string) ([]*domain.PullRequest, error) {
+	if len(userIDs) == 0 {
+		return []*domain.PullRequest{}, nil
+	}
+	reviewers, err := p.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := p.prRepo.GetOpenReviewCounts(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	freed := make(map[string]bool)
+	for _, reviewer := range reviewers {
+		if freed[reviewer.TeamName] {
+			continue
+		}
+		policy, err := resolveTeamPolicy(ctx, p.policyRepo, p.defaultPolicy, reviewer.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if atReviewLimit(policy, reviewer, counts[reviewer.UserID]+1) {
+			freed[reviewer.TeamName] = true
+		}
+	}
+	return p.processReviewQueue(ctx, freed, prID)
+}
+
+// TopUpTeam tops up open PRs that can take reviewers from teamName, authored
+// in it or in a team that falls back to it, and have fewer reviewers than the
+// review target, e.g. after the team gained active members. A PR that fails
+// to top up is logged and skipped.
+func (p *PullRequest) TopUpTeam(ctx context.Context, teamName string) ([]*domain.PullRequest, error) {
+	teamNames, err := p.teamRepo.GetAllNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	teams := map[string]bool{teamName: true}
+	updated := []*domain.PullRequest{}
+	for _, name := range teamNames {
+		served, err := p.takesReviewersFrom(ctx, name, teams)
+		if err != nil {
+			return nil, err
+		}
+		if !served {
+			continue
+		}
+		prs, err := p.prRepo.GetOpenByAuthorTeam(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			added, err := p.topUpReviewers(ctx, pr)
+			if err != nil {
+				log.Printf("failed to top up PR %s: %v", pr.PullRequestID, err)
+				continue
+			}
+			if len(added) > 0 {
+				updated = append(updated, pr)
+			}
+		}
+	}
+	return updated, nil
+}
+
+// topUpReviewers fills pr up to the review target.
+func (p *PullRequest) topUpReviewers(ctx context.Context, pr *domain.PullRequest) ([]string, error) {
+	author, err := p.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := resolveTeamPolicy(ctx, p.policyRepo, p.defaultPolicy, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	reviewers, err := p.prRepo.GetReviewers(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	change, err := p.planTopUp(ctx, pr, author, policy, reviewers, reviewTarget(policy), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(change.Added) > 0 || change.UnderReviewed != nil {
+		if err := p.prRepo.ApplyReviewerChange(ctx, change); err != nil {
+			return nil, err
+		}
+	}
+	if change.UnderReviewed != nil {
+		pr.UnderReviewed = *change.UnderReviewed
+	}
+	pr.AssignedReviewers = append(reviewers, change.Added...)
+	return change.Added, nil
+}
+
+// planTopUp picks reviewers that bring pr from reviewers up to target and
+// returns the change to apply, with the TOP_UP trace when anyone is added.
+// Users in removed are being taken off the PR and are not picked again;
+// pending works as in planReassign.
+func (p *PullRequest) planTopUp(ctx context.Context, pr *domain.PullRequest, author *domain.User, policy *domain.TeamPolicy, reviewers []string, target int, pending map[string]int, removed ...string) (*domain.ReviewerChange, error) {
+	hasSenior, err := p.hasSeniorReviewer(ctx, reviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &domain.ReviewerChange{PullRequestID: pr.PullRequestID, Removed: removed}
+	if missing := target - len(reviewers); missing > 0 {
+		excluded := map[string]domain.ExclusionReason{author.UserID: domain.ExclusionAuthor}
+		for _, reviewerID := range reviewers {
+			excluded[reviewerID] = domain.ExclusionAlreadyAssigned
+		}
+		for _, userID := range removed {
+			excluded[userID] = domain.ExclusionRemoved
+		}
+		teamPool, err := p.collectCandidates(ctx, author.TeamName, excluded)
+		if err != nil {
+			return nil, err
+		}
+		pools := []*candidatePool{teamPool}
+		if policy.AllowCrossTeam {
+			fallbackPools, err := p.collectFallbackCandidates(ctx, author.TeamName, map[string]bool{author.TeamName: true}, excluded)
+			if err != nil {
+				return nil, err
+			}
+			pools = append(pools, fallbackPools...)
+		}
+		rules, err := p.loadRuleEvaluator(ctx, author, pr.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+		labels, err := p.prRepo.GetLabels(ctx, pr.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+		minimum := policy.MinReviewers - len(reviewers)
+		if minimum < 0 {
+			minimum = 0
+		}
+
+		added, trace, err := p.assignReviewers(ctx, &assignmentRequest{
+			prID:             pr.PullRequestID,
+			action:           domain.AssignmentActionTopUp,
+			authorTeam:       author.TeamName,
+			pools:            pools,
+			rules:            rules,
+			labels:           labels,
+			requireSenior:    policy.RequireSenior && !hasSenior,
+			workingHours:     policy.WorkingHoursMode,
+			startWithinHours: policy.StartWithinHours,
+			count:            missing,
+			minimum:          minimum,
+			pending:          pending,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(added) > 0 {
+			change.Added = added
+			change.Trace = trace
+		}
+		hasSenior = hasSenior || trace.SeniorPick != ""
+	}
+
+	underReviewed := isUnderReviewed(policy, len(reviewers)+len(change.Added), hasSenior)
+	if underReviewed != pr.UnderReviewed {
+		change.UnderReviewed = &underReviewed
+	}
+	return change, nil
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"time"
+)
+
+type chosenReviewerCheck struct {
+	prID          string
+	action        domain.AssignmentAction
+	newReviewerID string
+	author        *domain.User
+	// oldReviewer is the reviewer being replaced, nil when adding one.
+	oldReviewer   *domain.User
+	reviewers     []string
+	policy        *domain.TeamPolicy
+	rules         *ruleEvaluator
+	labels        []string
+	requireSenior bool
+}
+
+// checkChosenReviewer applies the filters the strategy path uses to a
+// reviewer picked by hand and returns the trace to record for the swap.
+func (p *PullRequest) checkChosenReviewer(ctx context.Context, check *chosenReviewerCheck) (*domain.AssignmentTrace, error) {
+	reviewer, err := p.userRepo.GetByID(ctx, check.newReviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewer.UserID == check.author.UserID {
+		return nil, domain.NewDomainError(domain.ErrNoCandidate, "new reviewer is the PR author")
+	}
+	for _, reviewerID := range check.reviewers {
+		if reviewerID == reviewer.UserID {
+			return nil, domain.NewDomainError(domain.ErrNoCandidate, "new reviewer is already assigned to this PR")
+		}
+	}
+	if !reviewer.IsActive {
+		return nil, domain.NewDomainError(domain.ErrNoCandidate, "new reviewer is not active")
+	}
+	absent, err := p.userRepo.GetAbsentIDs(ctx, []string{reviewer.UserID}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if absent[reviewer.UserID] {
+		return nil, domain.NewDomainError(domain.ErrNoCandidate, "new reviewer is absent")
+	}
+	if !check.policy.AllowCrossTeam && reviewer.TeamName != check.author.TeamName {
+		return nil, domain.NewDomainError(domain.ErrNoCandidate, "team policy does not allow reviewers outside the author's team")
+	}
+	if check.policy.WorkingHoursMode == domain.WorkingHoursStrict && !isWithinWorkingHours(reviewer, time.Now(), check.policy.StartWithinHours) {
+		return nil, domain.NewDomainError(domain.ErrNoCandidate, "new reviewer is outside working hours")
+	}
+	if len(check.rules.violations(reviewer.UserID)) > 0 {
+		return nil, domain.NewDomainError(domain.ErrNoCandidate, "new reviewer is excluded by a reviewer rule")
+	}
+	atCapacity, loads, err := p.loadAtCapacity(ctx, []*candidatePool{{candidates: []*domain.User{reviewer}}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if atCapacity[reviewer.UserID] {
+		return nil, domain.NewDomainError(domain.ErrCapacityExhausted, "new reviewer is at capacity")
+	}
+	if check.requireSenior && check.oldReviewer != nil && check.oldReviewer.Level.IsSenior() && !reviewer.Level.IsSenior() {
+		return nil, domain.NewDomainError(domain.ErrNoCandidate, "new reviewer must be senior to keep the team's senior requirement")
+	}
+
+	strategy, _, err := p.resolveSelector(ctx, check.author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	trace := &domain.AssignmentTrace{
+		PullRequestID: check.prID,
+		Action:        check.action,
+		Strategy:      strategy,
+		Labels:        append([]string{}, check.labels...),
+		Candidates:    []string{reviewer.UserID},
+		Pools:         []*domain.CandidatePool{},
+		Excluded:      []*domain.ExcludedCandidate{},
+		Selected:      []string{reviewer.UserID},
+		Violations:    []*domain.RuleViolation{},
+		Loads:         loads,
+		CreatedAt:     time.Now(),
+	}
+	if check.requireSenior && reviewer.Level.IsSenior() {
+		trace.SeniorPick = reviewer.UserID
+	}
+	return trace, nil
+}
@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/repo"
+	"context"
+	"log"
+	"sort"
+	"time"
+)
+
+type RebalanceSettings struct {
+	// Interval between background runs; zero disables the job.
+	Interval time.Duration
+	// MaxAge keeps older assignments in place, treating them as already
+	// started; zero lets every open assignment move. Assignments with a
+	// submitted verdict are always treated as started.
+	MaxAge time.Duration
+	// MaxSkew is the open-review gap tolerated inside a team.
+	MaxSkew int
+}
+
+type Rebalancer struct {
+	prRepo           repo.PullRequestRepository
+	userRepo         repo.UserRepository
+	teamRepo         repo.TeamRepository
+	policyRepo       repo.TeamPolicyRepository
+	reassignmentRepo repo.ReassignmentRepository
+	reviews          *PullRequest
+	defaultPolicy    domain.TeamPolicy
+	settings         RebalanceSettings
+}
+
+func NewRebalancer(prRepo repo.PullRequestRepository, userRepo repo.UserRepository, teamRepo repo.TeamRepository, policyRepo repo.TeamPolicyRepository, reassignmentRepo repo.ReassignmentRepository, reviews *PullRequest, defaultPolicy domain.TeamPolicy, settings RebalanceSettings) *Rebalancer {
+	// A gap of one cannot be closed by moving a review, it only flips sides.
+	if settings.MaxSkew < 1 {
+		settings.MaxSkew = 1
+	}
+	return &Rebalancer{
+		prRepo:           prRepo,
+		userRepo:         userRepo,
+		teamRepo:         teamRepo,
+		policyRepo:       policyRepo,
+		reassignmentRepo: reassignmentRepo,
+		reviews:          reviews,
+		defaultPolicy:    defaultPolicy,
+		settings:         settings,
+	}
+}
+
+// Start runs the rebalancer every configured interval until ctx is done.
+func (r *Rebalancer) Start(ctx context.Context) {
+	if r.settings.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(r.settings.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := r.Rebalance(ctx, "", false)
+			if err != nil {
+				log.Printf("rebalance failed: %v", err)
+				continue
+			}
+			if len(report.Moves) > 0 {
+				log.Printf("rebalance moved %d reviews", len(report.Moves))
+			}
+		}
+	}
+}
+
+// Rebalance moves open reviews from overloaded to underloaded members of
+// teamName, or of every team when it is empty. With dryRun it only reports
+// the moves it would make.
+func (r *Rebalancer) Rebalance(ctx context.Context, teamName string, dryRun bool) (*domain.RebalanceReport, error) {
+	teamNames := []string{teamName}
+	if teamName == "" {
+		var err error
+		teamNames, err = r.teamRepo.GetAllNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		exists, err := r.teamRepo.Exists(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.NewDomainError(domain.ErrNotFound, "team not found")
+		}
+	}
+
+	report := &domain.RebalanceReport{
+		DryRun:      dryRun,
+		Teams:       []*domain.TeamLoadSkew{},
+		Moves:       []*domain.Reassignment{},
+		GeneratedAt: time.Now(),
+	}
+	for _, name := range teamNames {
+		if err := r.rebalanceTeam(ctx, name, dryRun, report); err != nil {
+			if teamName != "" {
+				return nil, err
+			}
+			log.Printf("failed to rebalance team %s: %v", name, err)
+		}
+	}
+	return report, nil
+}
+
+func (r *Rebalancer) ListReassignments(ctx context.Context, limit int) ([]*domain.Reassignment, error) {
+	return r.reassignmentRepo.ListRecent(ctx, limit)
+}
+
+func (r *Rebalancer) rebalanceTeam(ctx context.Context, teamName string, dryRun bool, report *domain.RebalanceReport) error {
+	members, err := r.userRepo.GetByTeamName(ctx, teamName)
+	if err != nil {
+		return err
+	}
+	var memberIDs []string
+	for _, member := range members {
+		memberIDs = append(memberIDs, member.UserID)
+	}
+	absent, err := r.userRepo.GetAbsentIDs(ctx, memberIDs, time.Now())
+	if err != nil {
+		return err
+	}
+	var active []*domain.User
+	var ids []string
+	for _, member := range members {
+		if member.IsActive && !absent[member.UserID] {
+			active = append(active, member)
+			ids = append(ids, member.UserID)
+		}
+	}
+	if len(active) < 2 {
+		return nil
+	}
+
+	// Review limits belong to the reviewer's team; everything else about a
+	// move follows the policy of the PR author's team.
+	policy, err := resolveTeamPolicy(ctx, r.policyRepo, r.defaultPolicy, teamName)
+	if err != nil {
+		return err
+	}
+	counts, err := r.prRepo.GetOpenReviewCounts(ctx, ids)
+	if err != nil {
+		return err
+	}
+	assignments, err := r.prRepo.GetOpenAssignments(ctx, ids, r.settings.MaxAge, true)
+	if err != nil {
+		return err
+	}
+	movable := make(map[string][]*domain.ReviewAssignment)
+	for _, assignment := range assignments {
+		movable[assignment.UserID] = append(movable[assignment.UserID], assignment)
+	}
+
+	skew := &domain.TeamLoadSkew{TeamName: teamName, SkewBefore: loadSkew(active, counts)}
+	state := &rebalanceState{
+		pullRequests: make(map[string]*rebalancePullRequest),
+		policies:     map[string]*domain.TeamPolicy{teamName: policy},
+		now:          time.Now(),
+	}
+	exhausted := make(map[string]bool)
+	freed := false
+	for {
+		donor := heaviestMember(active, counts, exhausted)
+		if donor == nil {
+			break
+		}
+		moved := false
+		for _, receiver := range lightestMembers(active, counts) {
+			if counts[donor.UserID]-counts[receiver.UserID] <= r.settings.MaxSkew {
+				break
+			}
+			if atReviewLimit(policy, receiver, counts[receiver.UserID]) {
+				continue
+			}
+			idx := r.findMovable(ctx, state, movable[donor.UserID], donor, receiver)
+			if idx < 0 {
+				continue
+			}
+
+			assignment := movable[donor.UserID][idx]
+			movable[donor.UserID] = append(movable[donor.UserID][:idx], movable[donor.UserID][idx+1:]...)
+			move := &domain.Reassignment{
+				PullRequestID: assignment.PullRequestID,
+				TeamName:      teamName,
+				FromUserID:    donor.UserID,
+				ToUserID:      receiver.UserID,
+				Reason:        domain.ReassignmentRebalance,
+				CreatedAt:     time.Now(),
+			}
+			if !dryRun {
+				err := r.prRepo.ApplyReviewerChange(ctx, &domain.ReviewerChange{
+					PullRequestID: move.PullRequestID,
+					Removed:       []string{move.FromUserID},
+					Added:         []string{move.ToUserID},
+					Reassignment:  move,
+				})
+				if err != nil {
+					log.Printf("failed to move review of pull request %s from %s to %s: %v", move.PullRequestID, move.FromUserID, move.ToUserID, err)
+					continue
+				}
+			}
+			report.Moves = append(report.Moves, move)
+
+			if atReviewLimit(policy, donor, counts[donor.UserID]) {
+				freed = true
+			}
+			counts[donor.UserID]--
+			counts[receiver.UserID]++
+			reviewers := state.pullRequests[move.PullRequestID].reviewers
+			delete(reviewers, move.FromUserID)
+			reviewers[move.ToUserID] = true
+			moved = true
+			break
+		}
+		if !moved {
+			exhausted[donor.UserID] = true
+		}
+	}
+
+	skew.SkewAfter = loadSkew(active, counts)
+	report.Teams = append(report.Teams, skew)
+
+	if freed && !dryRun {
+		if _, err := r.reviews.processReviewQueue(ctx, map[string]bool{teamName: true}, ""); err != nil {
+			log.Printf("failed to process review queue for team %s: %v", teamName, err)
+		}
+	}
+	return nil
+}
+
+type rebalanceState struct {
+	pullRequests map[string]*rebalancePullRequest
+	policies     map[string]*domain.TeamPolicy
+	now          time.Time
+}
+
+type rebalancePullRequest struct {
+	author    *domain.User
+	policy    *domain.TeamPolicy
+	reviewers map[string]bool
+	evaluator *ruleEvaluator
+}
+
+// findMovable returns the index of the newest assignment that can go from
+// donor to receiver without reviewing their own PR, doubling up on a PR or
+// breaking the author's policy and reviewer rules, or -1 when there is none.
+// Assignments whose PR fails to load are logged and skipped.
+func (r *Rebalancer) findMovable(ctx context.Context, state *rebalanceState, assignments []*domain.ReviewAssignment, donor *domain.User, receiver *domain.User) int {
+	for i, assignment := range assignments {
+		if assignment.AuthorID == receiver.UserID {
+			continue
+		}
+		pr, ok := state.pullRequests[assignment.PullRequestID]
+		if !ok {
+			var err error
+			pr, err = r.loadPullRequest(ctx, state, assignment)
+			if err != nil {
+				log.Printf("failed to load pull request %s for rebalancing: %v", assignment.PullRequestID, err)
+			}
+			state.pullRequests[assignment.PullRequestID] = pr
+		}
+		if pr == nil || pr.reviewers[receiver.UserID] {
+			continue
+		}
+		if !pr.policy.AllowCrossTeam && receiver.TeamName != pr.author.TeamName {
+			continue
+		}
+		if pr.policy.RequireSenior && donor.Level.IsSenior() && !receiver.Level.IsSenior() {
+			continue
+		}
+		if pr.policy.WorkingHoursMode == domain.WorkingHoursStrict && !isWithinWorkingHours(receiver, state.now, pr.policy.StartWithinHours) {
+			continue
+		}
+		if len(pr.evaluator.violations(receiver.UserID)) > 0 {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+func (r *Rebalancer) loadPullRequest(ctx context.Context, state *rebalanceState, assignment *domain.ReviewAssignment) (*rebalancePullRequest, error) {
+	author, err := r.userRepo.GetByID(ctx, assignment.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	policy, ok := state.policies[author.TeamName]
+	if !ok {
+		policy, err = resolveTeamPolicy(ctx, r.policyRepo, r.defaultPolicy, author.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		state.policies[author.TeamName] = policy
+	}
+	ids, err := r.prRepo.GetReviewers(ctx, assignment.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	reviewers := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		reviewers[id] = true
+	}
+	evaluator, err := r.reviews.loadRuleEvaluator(ctx, author, assignment.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return &rebalancePullRequest{
+		author:    author,
+		policy:    policy,
+		reviewers: reviewers,
+		evaluator: evaluator,
+	}, nil
+}
+
+func heaviestMember(members []*domain.User, counts map[string]int, exhausted map[string]bool) *domain.User {
+	var heaviest *domain.User
+	for _, member := range members {
+		if exhausted[member.UserID] {
+			continue
+		}
+		if heaviest == nil || counts[member.UserID] > counts[heaviest.UserID] {
+			heaviest = member
+		}
+	}
+	return heaviest
+}
+
+func lightestMembers(members []*domain.User, counts map[string]int) []*domain.User {
+	sorted := append([]*domain.User(nil), members...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return counts[sorted[i].UserID] < counts[sorted[j].UserID]
+	})
+	return sorted
+}
+
+func loadSkew(members []*domain.User, counts map[string]int) int {
+	if len(members) == 0 {
+		return 0
+	}
+	lowest, highest := counts[members[0].UserID], counts[members[0].UserID]
+	for _, member := range members[1:] {
+		count := counts[member.UserID]
+		if count < lowest {
+			lowest = count
+		}
+		if count > highest {
+			highest = count
+		}
+	}
+	return highest - lowest
+}
@@ -0,0 +1,380 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"sort"
+	"time"
+)
+
+// RedistributeReviews deactivates userIDs and hands their open reviews to
+// the least loaded eligible reviewer of the author's team or its fallback
+// teams, dropping an assignment when nobody is eligible. Everything is loaded up
+// front and written back in one transaction together with the deactivation,
+// so whole teams can be moved at once and a failure changes nothing; team
+// strategies and label matching are not applied here.
+func (p *PullRequest) RedistributeReviews(ctx context.Context, userIDs []string) ([]*domain.PullRequestRedistribution, error) {
+	if len(userIDs) == 0 {
+		return []*domain.PullRequestRedistribution{}, nil
+	}
+	assignments, err := p.prRepo.GetOpenAssignments(ctx, userIDs, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	report, redistribution, err := p.planRedistribution(ctx, assignments, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	redistribution.Deactivate = userIDs
+	if err := p.prRepo.ApplyRedistribution(ctx, redistribution); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// planRedistribution plans the handoff of assignments the way
+// RedistributeReviews does, without writing anything. Users in departing are
+// never picked as replacements.
+func (p *PullRequest) planRedistribution(ctx context.Context, assignments []*domain.ReviewAssignment, departing []string) ([]*domain.PullRequestRedistribution, *domain.ReviewRedistribution, error) {
+	report := []*domain.PullRequestRedistribution{}
+	if len(assignments) == 0 {
+		return report, &domain.ReviewRedistribution{}, nil
+	}
+
+	leavingByPR := make(map[string][]string)
+	var prIDs []string
+	for _, assignment := range assignments {
+		if _, ok := leavingByPR[assignment.PullRequestID]; !ok {
+			prIDs = append(prIDs, assignment.PullRequestID)
+		}
+		leavingByPR[assignment.PullRequestID] = append(leavingByPR[assignment.PullRequestID], assignment.UserID)
+	}
+	sort.Strings(prIDs)
+
+	prs, err := p.prRepo.GetByIDs(ctx, prIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	reviewersByPR, err := p.prRepo.GetReviewersByPRs(ctx, prIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := p.newRedistributor(ctx, prs, reviewersByPR, departing)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prByID := make(map[string]*domain.PullRequest, len(prs))
+	for _, pr := range prs {
+		prByID[pr.PullRequestID] = pr
+	}
+	var swaps []*domain.ReviewerSwap
+	var traces []*domain.AssignmentTrace
+	var nowUnderReviewed, nowReviewed []string
+	for _, prID := range prIDs {
+		pr, ok := prByID[prID]
+		if !ok {
+			continue
+		}
+		author := r.users[pr.AuthorID]
+		policy, err := r.policy(ctx, author.TeamName)
+		if err != nil {
+			return nil, nil, err
+		}
+		leaving := leavingByPR[prID]
+		sort.Strings(leaving)
+		current := make(map[string]bool)
+		for _, reviewerID := range reviewersByPR[prID] {
+			current[reviewerID] = true
+		}
+		for _, userID := range leaving {
+			delete(current, userID)
+		}
+
+		evaluator, err := r.evaluator(ctx, author, prID)
+		if err != nil {
+			return nil, nil, err
+		}
+		entry := &domain.PullRequestRedistribution{PullRequestID: prID, Handoffs: []*domain.ReviewHandoff{}}
+		for _, userID := range leaving {
+			oldReviewer := r.users[userID]
+			requireSenior := policy.RequireSenior && oldReviewer.Level.IsSenior() && !r.hasSenior(current)
+			replacement, pools, err := r.pick(ctx, author, policy, evaluator, current, requireSenior)
+			if err != nil {
+				return nil, nil, err
+			}
+			if replacement == nil && requireSenior {
+				replacement, pools, err = r.pick(ctx, author, policy, evaluator, current, false)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+
+			swap := &domain.ReviewerSwap{PullRequestID: prID, FromUserID: userID}
+			handoff := &domain.ReviewHandoff{PullRequestID: prID, FromUserID: userID, Outcome: domain.HandoffRemoved}
+			if replacement != nil {
+				swap.ToUserID = replacement.UserID
+				handoff.Outcome = domain.HandoffReassigned
+				handoff.ReplacedBy = replacement.UserID
+				traces = append(traces, r.trace(prID, pools, replacement))
+				current[replacement.UserID] = true
+				r.counts[replacement.UserID]++
+			}
+			swaps = append(swaps, swap)
+			entry.Handoffs = append(entry.Handoffs, handoff)
+		}
+
+		entry.UnderReviewed = isUnderReviewed(policy, len(current), r.hasSenior(current))
+		if entry.UnderReviewed != pr.UnderReviewed {
+			if entry.UnderReviewed {
+				nowUnderReviewed = append(nowUnderReviewed, prID)
+			} else {
+				nowReviewed = append(nowReviewed, prID)
+			}
+		}
+		report = append(report, entry)
+	}
+
+	return report, &domain.ReviewRedistribution{
+		Swaps:            swaps,
+		Traces:           traces,
+		NowUnderReviewed: nowUnderReviewed,
+		NowReviewed:      nowReviewed,
+	}, nil
+}
+
+// redistributor caches everything RedistributeReviews looks up per team so
+// that each team, policy and load count is read once.
+type redistributor struct {
+	p         *PullRequest
+	now       time.Time
+	users     map[string]*domain.User
+	policies  map[string]*domain.TeamPolicy
+	members   map[string][]*domain.User
+	fallbacks map[string][]string
+	counts    map[string]int
+	rules     map[string]*ruleEvaluator
+	global    []*domain.ReviewerRule
+	byAuthor  map[string][]*domain.ReviewerRule
+	// departing are being deactivated and never picked as replacements.
+	departing []string
+}
+
+func (p *PullRequest) newRedistributor(ctx context.Context, prs []*domain.PullRequest, reviewersByPR map[string][]string, departing []string) (*redistributor, error) {
+	seen := make(map[string]bool)
+	var ids []string
+	add := func(userID string) {
+		if !seen[userID] {
+			seen[userID] = true
+			ids = append(ids, userID)
+		}
+	}
+	for _, pr := range prs {
+		add(pr.AuthorID)
+		for _, reviewerID := range reviewersByPR[pr.PullRequestID] {
+			add(reviewerID)
+		}
+	}
+	users, err := p.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := p.ruleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &redistributor{
+		p:         p,
+		now:       time.Now(),
+		users:     make(map[string]*domain.User, len(users)),
+		policies:  make(map[string]*domain.TeamPolicy),
+		members:   make(map[string][]*domain.User),
+		fallbacks: make(map[string][]string),
+		counts:    make(map[string]int),
+		rules:     make(map[string]*ruleEvaluator),
+		byAuthor:  make(map[string][]*domain.ReviewerRule),
+		departing: departing,
+	}
+	for _, user := range users {
+		r.users[user.UserID] = user
+	}
+	for _, rule := range rules {
+		if rule.AuthorID == "" {
+			r.global = append(r.global, rule)
+		} else {
+			r.byAuthor[rule.AuthorID] = append(r.byAuthor[rule.AuthorID], rule)
+		}
+	}
+	return r, nil
+}
+
+// pick returns the least loaded eligible candidate of the first team in the
+// author's pool that has one, along with the eligible candidates of every
+// team it looked at.
+func (r *redistributor) pick(ctx context.Context, author *domain.User, policy *domain.TeamPolicy, evaluator *ruleEvaluator, current map[string]bool, requireSenior bool) (*domain.User, []*domain.CandidatePool, error) {
+	teams := []string{author.TeamName}
+	if policy.AllowCrossTeam {
+		fallbackTeams, err := r.fallbackTeams(ctx, author.TeamName)
+		if err != nil {
+			return nil, nil, err
+		}
+		teams = append(teams, fallbackTeams...)
+	}
+
+	var pools []*domain.CandidatePool
+	visited := make(map[string]bool)
+	for _, teamName := range teams {
+		if visited[teamName] {
+			continue
+		}
+		visited[teamName] = true
+		members, err := r.activeMembers(ctx, teamName)
+		if err != nil {
+			return nil, nil, err
+		}
+		pool := &domain.CandidatePool{Source: domain.ReviewerSourceTeam, TeamName: teamName, Candidates: []string{}, Selected: []string{}}
+		if teamName != author.TeamName {
+			pool.Source = domain.ReviewerSourceFallback
+		}
+		pools = append(pools, pool)
+		var best *domain.User
+		for _, candidate := range members {
+			if candidate.UserID == author.UserID || current[candidate.UserID] {
+				continue
+			}
+			if requireSenior && !candidate.Level.IsSenior() {
+				continue
+			}
+			if policy.WorkingHoursMode == domain.WorkingHoursStrict && !isWithinWorkingHours(candidate, r.now, policy.StartWithinHours) {
+				continue
+			}
+			if len(evaluator.violations(candidate.UserID)) > 0 {
+				continue
+			}
+			candidatePolicy, err := r.policy(ctx, candidate.TeamName)
+			if err != nil {
+				return nil, nil, err
+			}
+			if atReviewLimit(candidatePolicy, candidate, r.counts[candidate.UserID]) {
+				continue
+			}
+			pool.Candidates = append(pool.Candidates, candidate.UserID)
+			if best == nil || r.counts[candidate.UserID] < r.counts[best.UserID] {
+				best = candidate
+			}
+		}
+		if best != nil {
+			pool.Selected = append(pool.Selected, best.UserID)
+			return best, pools, nil
+		}
+	}
+	return nil, pools, nil
+}
+
+// trace records a replacement picked for prID as a REASSIGN trace.
+func (r *redistributor) trace(prID string, pools []*domain.CandidatePool, replacement *domain.User) *domain.AssignmentTrace {
+	trace := &domain.AssignmentTrace{
+		PullRequestID: prID,
+		Action:        domain.AssignmentActionReassign,
+		Strategy:      domain.StrategyLeastLoaded,
+		Labels:        []string{},
+		Candidates:    []string{},
+		Pools:         pools,
+		Excluded:      []*domain.ExcludedCandidate{},
+		Selected:      []string{replacement.UserID},
+		Violations:    []*domain.RuleViolation{},
+		Loads:         make(map[string]int),
+		CreatedAt:     r.now,
+	}
+	for _, pool := range pools {
+		for _, candidateID := range pool.Candidates {
+			trace.Candidates = append(trace.Candidates, candidateID)
+			trace.Loads[candidateID] = r.counts[candidateID]
+		}
+	}
+	return trace
+}
+
+func (r *redistributor) activeMembers(ctx context.Context, teamName string) ([]*domain.User, error) {
+	if members, ok := r.members[teamName]; ok {
+		return members, nil
+	}
+	members, err := r.p.userRepo.GetActiveByTeamExcluding(ctx, teamName, r.departing)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		r.users[member.UserID] = member
+		ids = append(ids, member.UserID)
+	}
+	counts, err := r.p.prRepo.GetOpenReviewCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for userID, count := range counts {
+		r.counts[userID] = count
+	}
+	r.members[teamName] = members
+	return members, nil
+}
+
+func (r *redistributor) fallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	if fallbackTeams, ok := r.fallbacks[teamName]; ok {
+		return fallbackTeams, nil
+	}
+	fallbackTeams, err := r.p.teamRepo.GetFallbackTeams(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	r.fallbacks[teamName] = fallbackTeams
+	return fallbackTeams, nil
+}
+
+func (r *redistributor) policy(ctx context.Context, teamName string) (*domain.TeamPolicy, error) {
+	if policy, ok := r.policies[teamName]; ok {
+		return policy, nil
+	}
+	policy, err := resolveTeamPolicy(ctx, r.p.policyRepo, r.p.defaultPolicy, teamName)
+	if err != nil {
+		return nil, err
+	}
+	r.policies[teamName] = policy
+	return policy, nil
+}
+
+// evaluator returns the rule evaluator for author's PR prID, cached per PR
+// since NO_REPEAT depends on it. The reviewers of the author's previous PR
+// are only loaded when a NO_REPEAT rule applies.
+func (r *redistributor) evaluator(ctx context.Context, author *domain.User, prID string) (*ruleEvaluator, error) {
+	if evaluator, ok := r.rules[prID]; ok {
+		return evaluator, nil
+	}
+	rules := append(append([]*domain.ReviewerRule{}, r.byAuthor[author.UserID]...), r.global...)
+	evaluator := &ruleEvaluator{rules: rules, authorLevel: author.Level, previousReviewers: make(map[string]bool)}
+	for _, rule := range rules {
+		if rule.Type != domain.RuleNoRepeat {
+			continue
+		}
+		previous, err := r.p.prRepo.GetLastReviewersByAuthor(ctx, author.UserID, prID)
+		if err != nil {
+			return nil, err
+		}
+		for _, reviewerID := range previous {
+			evaluator.previousReviewers[reviewerID] = true
+		}
+		break
+	}
+	r.rules[prID] = evaluator
+	return evaluator, nil
+}
+
+func (r *redistributor) hasSenior(reviewers map[string]bool) bool {
+	for reviewerID := range reviewers {
+		if user, ok := r.users[reviewerID]; ok && user.Level.IsSenior() {
+			return true
+		}
+	}
+	return false
+}
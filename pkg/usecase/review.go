@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"time"
+)
+
+// SubmitReview records a verdict from an assigned reviewer of an open PR.
+// Earlier verdicts are kept; the latest APPROVED or CHANGES_REQUESTED one is
+// the reviewer's state, which a later COMMENTED does not withdraw.
+func (p *PullRequest) SubmitReview(ctx context.Context, prID, userID string, verdict domain.ReviewVerdict, body string) (*domain.PullRequest, *domain.Review, error) {
+	if !verdict.IsValid() {
+		return nil, nil, domain.NewDomainError(domain.ErrInvalidVerdict, "unknown review verdict")
+	}
+	pr, err := p.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch pr.Status {
+	case domain.PRStatusMerged:
+		return nil, nil, domain.NewDomainError(domain.ErrPRMerged, "cannot review merged PR")
+	case domain.PRStatusClosed:
+		return nil, nil, domain.NewDomainError(domain.ErrPRClosed, "cannot review closed PR")
+	case domain.PRStatusDraft:
+		return nil, nil, domain.NewDomainError(domain.ErrPRDraft, "cannot review draft PR")
+	}
+	reviewers, err := p.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	isAssigned := false
+	for _, reviewerID := range reviewers {
+		if reviewerID == userID {
+			isAssigned = true
+			break
+		}
+	}
+	if !isAssigned {
+		return nil, nil, domain.NewDomainError(domain.ErrNotAssigned, "reviewer is not assigned to this PR")
+	}
+
+	review := &domain.Review{
+		PullRequestID: prID,
+		UserID:        userID,
+		Verdict:       verdict,
+		Body:          body,
+		CreatedAt:     time.Now(),
+	}
+	if err := p.reviewRepo.Create(ctx, review); err != nil {
+		return nil, nil, err
+	}
+	pr.AssignedReviewers = reviewers
+	if err := p.attachReviewerStates(ctx, pr); err != nil {
+		return nil, nil, err
+	}
+	return pr, review, nil
+}
+
+// GetReviews returns the full verdict history of prID, oldest first.
+func (p *PullRequest) GetReviews(ctx context.Context, prID string) ([]*domain.Review, error) {
+	exists, err := p.prRepo.Exists(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.NewDomainError(domain.ErrNotFound, "pull request not found")
+	}
+	return p.reviewRepo.GetByPullRequestID(ctx, prID)
+}
+
+// attachReviewerStates fills pr.Reviewers from pr.AssignedReviewers and
+// their states as described on SubmitReview. Verdicts of reviewers no longer assigned are
+// ignored.
+func (p *PullRequest) attachReviewerStates(ctx context.Context, pr *domain.PullRequest) error {
+	history, err := p.reviewRepo.GetByPullRequestID(ctx, pr.PullRequestID)
+	if err != nil {
+		return err
+	}
+	pr.Reviewers = reviewerStates(pr.AssignedReviewers, history)
+	return nil
+}
+
+func reviewerStates(reviewerIDs []string, history []*domain.Review) []*domain.ReviewerState {
+	latest := make(map[string]*domain.Review, len(reviewerIDs))
+	for _, review := range history {
+		// A comment only counts until the reviewer approves or asks for changes.
+		if current, ok := latest[review.UserID]; ok && review.Verdict == domain.VerdictCommented && current.Verdict != domain.VerdictCommented {
+			continue
+		}
+		latest[review.UserID] = review
+	}
+	states := make([]*domain.ReviewerState, 0, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		state := &domain.ReviewerState{UserID: reviewerID, State: domain.ReviewerStatePending}
+		if review, ok := latest[reviewerID]; ok {
+			state.State = string(review.Verdict)
+			state.ReviewedAt = &review.CreatedAt
+		}
+		states = append(states, state)
+	}
+	return states
+}
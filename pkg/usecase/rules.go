@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/repo"
+	"context"
+	"time"
+)
+
+type ReviewerRules struct {
+	ruleRepo repo.ReviewerRuleRepository
+	userRepo repo.UserRepository
+}
+
+func NewReviewerRules(ruleRepo repo.ReviewerRuleRepository, userRepo repo.UserRepository) *ReviewerRules {
+	return &ReviewerRules{
+		ruleRepo: ruleRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (r *ReviewerRules) CreateRule(ctx context.Context, rule *domain.ReviewerRule) (*domain.ReviewerRule, error) {
+	if !rule.Type.IsValid() {
+		return nil, domain.NewDomainError(domain.ErrInvalidRule, "unknown rule type")
+	}
+	for _, level := range []domain.UserLevel{rule.AuthorLevel, rule.ReviewerLevel} {
+		if level != "" && !level.IsValid() {
+			return nil, domain.NewDomainError(domain.ErrInvalidRule, "unknown level")
+		}
+	}
+	if rule.Type != domain.RulePrefer && (rule.AuthorLevel != "" || rule.ReviewerLevel != "") {
+		return nil, domain.NewDomainError(domain.ErrInvalidRule, "levels are supported only for PREFER")
+	}
+	switch {
+	case rule.Type == domain.RulePrefer && rule.ReviewerLevel != "":
+		if rule.AuthorID != "" || rule.ReviewerID != "" {
+			return nil, domain.NewDomainError(domain.ErrInvalidRule, "a level-based rule does not take author_id or reviewer_id")
+		}
+	case rule.Type == domain.RulePrefer && rule.AuthorLevel != "":
+		return nil, domain.NewDomainError(domain.ErrInvalidRule, "reviewer_level is required with author_level")
+	case rule.Type == domain.RuleAvoid, rule.Type == domain.RulePrefer:
+		if rule.AuthorID == "" || rule.ReviewerID == "" {
+			return nil, domain.NewDomainError(domain.ErrInvalidRule, "author_id and reviewer_id are required")
+		}
+		if rule.AuthorID == rule.ReviewerID {
+			return nil, domain.NewDomainError(domain.ErrInvalidRule, "author_id and reviewer_id must differ")
+		}
+	case rule.Type == domain.RuleNoRepeat:
+		if rule.ReviewerID != "" {
+			return nil, domain.NewDomainError(domain.ErrInvalidRule, "reviewer_id is not supported for NO_REPEAT")
+		}
+	}
+	for _, userID := range []string{rule.AuthorID, rule.ReviewerID} {
+		if userID == "" {
+			continue
+		}
+		exists, err := r.userRepo.Exists(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.NewDomainError(domain.ErrNotFound, "user not found")
+		}
+	}
+
+	rule.CreatedAt = time.Now()
+	if err := r.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *ReviewerRules) DeleteRule(ctx context.Context, ruleID int64) error {
+	return r.ruleRepo.Delete(ctx, ruleID)
+}
+
+func (r *ReviewerRules) ListRules(ctx context.Context) ([]*domain.ReviewerRule, error) {
+	return r.ruleRepo.List(ctx)
+}
+
+type ruleEvaluator struct {
+	rules             []*domain.ReviewerRule
+	authorLevel       domain.UserLevel
+	previousReviewers map[string]bool
+}
+
+func (p *PullRequest) loadRuleEvaluator(ctx context.Context, author *domain.User, prID string) (*ruleEvaluator, error) {
+	authorID := author.UserID
+	rules, err := p.ruleRepo.GetForAuthor(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	evaluator := &ruleEvaluator{
+		rules:             rules,
+		authorLevel:       author.Level,
+		previousReviewers: make(map[string]bool),
+	}
+	for _, rule := range rules {
+		if rule.Type != domain.RuleNoRepeat {
+			continue
+		}
+		previous, err := p.prRepo.GetLastReviewersByAuthor(ctx, authorID, prID)
+		if err != nil {
+			return nil, err
+		}
+		for _, reviewerID := range previous {
+			evaluator.previousReviewers[reviewerID] = true
+		}
+		break
+	}
+	return evaluator, nil
+}
+
+func (e *ruleEvaluator) violations(reviewerID string) []*domain.RuleViolation {
+	if e == nil {
+		return nil
+	}
+	var violations []*domain.RuleViolation
+	for _, rule := range e.rules {
+		switch rule.Type {
+		case domain.RuleAvoid:
+			if rule.ReviewerID != reviewerID {
+				continue
+			}
+		case domain.RuleNoRepeat:
+			if !e.previousReviewers[reviewerID] {
+				continue
+			}
+		default:
+			continue
+		}
+		violations = append(violations, &domain.RuleViolation{RuleID: rule.ID, Type: rule.Type, ReviewerID: reviewerID})
+	}
+	return violations
+}
+
+// score counts the PREFER rules the reviewer matches, either by id or, for
+// level-based rules, by the reviewer's and the author's levels.
+func (e *ruleEvaluator) score(reviewer *domain.User) int {
+	if e == nil {
+		return 0
+	}
+	score := 0
+	for _, rule := range e.rules {
+		if rule.Type != domain.RulePrefer {
+			continue
+		}
+		if rule.ReviewerLevel != "" {
+			if rule.ReviewerLevel == reviewer.Level && (rule.AuthorLevel == "" || rule.AuthorLevel == e.authorLevel) {
+				score++
+			}
+			continue
+		}
+		if rule.ReviewerID == reviewer.UserID {
+			score++
+		}
+	}
+	return score
+}
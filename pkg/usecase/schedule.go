@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"fmt"
+	"time"
+	_ "time/tzdata"
+)
+
+const minutesPerDay = 24 * 60
+
+func parseClock(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func validateSchedule(userID, timezone, workStart, workEnd string) error {
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return domain.NewDomainError(domain.ErrInvalidSchedule, fmt.Sprintf("user %s: unknown timezone %q", userID, timezone))
+		}
+	}
+	if (workStart == "") != (workEnd == "") {
+		return domain.NewDomainError(domain.ErrInvalidSchedule, fmt.Sprintf("user %s: work_start and work_end must be set together", userID))
+	}
+	for _, value := range []string{workStart, workEnd} {
+		if value == "" {
+			continue
+		}
+		if _, err := parseClock(value); err != nil {
+			return domain.NewDomainError(domain.ErrInvalidSchedule, fmt.Sprintf("user %s: working hours must use HH:MM, got %q", userID, value))
+		}
+	}
+	return nil
+}
+
+// isWithinWorkingHours reports whether the user is at work at now, or starts
+// within startWithin hours. Users without a complete schedule are always available.
+func isWithinWorkingHours(user *domain.User, now time.Time, startWithin int) bool {
+	if user.Timezone == "" || user.WorkStart == "" || user.WorkEnd == "" {
+		return true
+	}
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return true
+	}
+	start, err := parseClock(user.WorkStart)
+	if err != nil {
+		return true
+	}
+	end, err := parseClock(user.WorkEnd)
+	if err != nil {
+		return true
+	}
+
+	local := now.In(location)
+	current := local.Hour()*60 + local.Minute()
+	switch {
+	case start == end:
+		return true
+	case start < end && current >= start && current < end:
+		return true
+	case start > end && (current >= start || current < end):
+		return true
+	}
+	untilStart := (start - current + minutesPerDay) % minutesPerDay
+	return untilStart <= startWithin*60
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/repo"
+	"context"
+	"math/rand"
+	"sort"
+)
+
+// SelectionState is what selectors decide on besides the seed: open-review
+// counts and round-robin cursors as they were when the assignment started.
+// It is recorded in the trace so that the selection can be replayed.
+type SelectionState struct {
+	Loads map[string]int
+	// StartCursors holds each team's cursor before the first pick, Cursors
+	// the cursor after the latest one.
+	StartCursors map[string]string
+	Cursors      map[string]string
+}
+
+func newSelectionState(loads map[string]int) *SelectionState {
+	return &SelectionState{
+		Loads:        loads,
+		StartCursors: make(map[string]string),
+		Cursors:      make(map[string]string),
+	}
+}
+
+type ReviewerSelector interface {
+	Select(ctx context.Context, state *SelectionState, teamName string, candidates []*domain.User, count int, rnd *rand.Rand) ([]string, error)
+}
+
+type RandomSelector struct{}
+
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{}
+}
+
+func (s *RandomSelector) Select(ctx context.Context, state *SelectionState, teamName string, candidates []*domain.User, count int, rnd *rand.Rand) ([]string, error) {
+	return userIDs(shuffleUsers(candidates, rnd), count), nil
+}
+
+type RoundRobinSelector struct {
+	teamRepo repo.TeamRepository
+}
+
+func NewRoundRobinSelector(teamRepo repo.TeamRepository) *RoundRobinSelector {
+	return &RoundRobinSelector{
+		teamRepo: teamRepo,
+	}
+}
+
+// Select rotates past the team's cursor and moves it in state; the caller
+// persists state.Cursors once the assignment is written.
+func (s *RoundRobinSelector) Select(ctx context.Context, state *SelectionState, teamName string, candidates []*domain.User, count int, rnd *rand.Rand) ([]string, error) {
+	if len(candidates) == 0 || count <= 0 {
+		return []string{}, nil
+	}
+	ordered := make([]*domain.User, len(candidates))
+	copy(ordered, candidates)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].UserID < ordered[j].UserID
+	})
+	cursor, ok := state.Cursors[teamName]
+	if !ok {
+		var err error
+		cursor, err = s.teamRepo.GetRoundRobinCursor(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		state.StartCursors[teamName] = cursor
+	}
+	start := sort.Search(len(ordered), func(i int) bool {
+		return ordered[i].UserID > cursor
+	})
+	rotated := append(append([]*domain.User{}, ordered[start:]...), ordered[:start]...)
+	reviewers := userIDs(rotated, count)
+	state.Cursors[teamName] = reviewers[len(reviewers)-1]
+
+	return reviewers, nil
+}
+
+type LeastLoadedSelector struct{}
+
+func NewLeastLoadedSelector() *LeastLoadedSelector {
+	return &LeastLoadedSelector{}
+}
+
+func (s *LeastLoadedSelector) Select(ctx context.Context, state *SelectionState, teamName string, candidates []*domain.User, count int, rnd *rand.Rand) ([]string, error) {
+	if len(candidates) == 0 {
+		return []string{}, nil
+	}
+	load := state.Loads
+	shuffled := shuffleUsers(candidates, rnd)
+	sort.SliceStable(shuffled, func(i, j int) bool {
+		return load[shuffled[i].UserID] < load[shuffled[j].UserID]
+	})
+
+	return userIDs(shuffled, count), nil
+}
+
+type WeightedSelector struct{}
+
+func NewWeightedSelector() *WeightedSelector {
+	return &WeightedSelector{}
+}
+
+func (s *WeightedSelector) Select(ctx context.Context, state *SelectionState, teamName string, candidates []*domain.User, count int, rnd *rand.Rand) ([]string, error) {
+	if len(candidates) == 0 {
+		return []string{}, nil
+	}
+	load := state.Loads
+	pool := make([]*domain.User, len(candidates))
+	copy(pool, candidates)
+	var picked []*domain.User
+	for len(picked) < count && len(pool) > 0 {
+		total := 0.0
+		for _, user := range pool {
+			total += 1 / float64(1+load[user.UserID])
+		}
+		point := rnd.Float64() * total
+		idx := len(pool) - 1
+		for i, user := range pool {
+			point -= 1 / float64(1+load[user.UserID])
+			if point < 0 {
+				idx = i
+				break
+			}
+		}
+		picked = append(picked, pool[idx])
+		pool = append(pool[:idx], pool[idx+1:]...)
+	}
+
+	return userIDs(picked, count), nil
+}
+
+func shuffleUsers(users []*domain.User, rnd *rand.Rand) []*domain.User {
+	shuffled := make([]*domain.User, len(users))
+	copy(shuffled, users)
+	rnd.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+func userIDs(users []*domain.User, limit int) []string {
+	if len(users) < limit {
+		limit = len(users)
+	}
+	if limit < 0 {
+		limit = 0
+	}
+	ids := make([]string, limit)
+	for i := 0; i < limit; i++ {
+		ids[i] = users[i].UserID
+	}
+	return ids
+}
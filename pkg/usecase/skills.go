@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"strings"
+	"time"
+)
+
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+type candidateScore struct {
+	preferred int
+	available int
+	skills    int
+}
+
+func (s candidateScore) greater(other candidateScore) bool {
+	if s.preferred != other.preferred {
+		return s.preferred > other.preferred
+	}
+	if s.available != other.available {
+		return s.available > other.available
+	}
+	return s.skills > other.skills
+}
+
+type candidateScorer struct {
+	rules    *ruleEvaluator
+	labels   map[string]bool
+	skills   map[string][]string
+	offHours map[string]bool
+}
+
+func (p *PullRequest) loadCandidateScorer(ctx context.Context, req *assignmentRequest) (*candidateScorer, error) {
+	scorer := &candidateScorer{
+		rules:    req.rules,
+		labels:   make(map[string]bool, len(req.labels)),
+		offHours: make(map[string]bool),
+	}
+	now := time.Now()
+	var ids []string
+	for _, pool := range req.pools {
+		for _, user := range pool.candidates {
+			ids = append(ids, user.UserID)
+			if req.workingHours != domain.WorkingHoursOff && !isWithinWorkingHours(user, now, req.startWithinHours) {
+				scorer.offHours[user.UserID] = true
+			}
+		}
+	}
+	for _, label := range req.labels {
+		scorer.labels[label] = true
+	}
+	if len(req.labels) == 0 {
+		return scorer, nil
+	}
+	skills, err := p.userRepo.GetSkills(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	scorer.skills = skills
+	return scorer, nil
+}
+
+func (s *candidateScorer) score(user *domain.User) candidateScore {
+	userID := user.UserID
+	score := candidateScore{preferred: s.rules.score(user)}
+	if !s.offHours[userID] {
+		score.available = 1
+	}
+	for _, skill := range s.skills[userID] {
+		if s.labels[skill] {
+			score.skills++
+		}
+	}
+	return score
+}
+
+func (t *Team) attachSkills(ctx context.Context, members []*domain.TeamMember) error {
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.UserID)
+	}
+	skills, err := t.userRepo.GetSkills(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		member.Skills = skills[member.UserID]
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"Avito/pkg/repo"
+	"context"
+)
+
+type Stats struct {
+	userRepo    repo.UserRepository
+	teamRepo    repo.TeamRepository
+	prRepo      repo.PullRequestRepository
+	declineRepo repo.ReviewDeclineRepository
+}
+
+func NewStats(userRepo repo.UserRepository, teamRepo repo.TeamRepository, prRepo repo.PullRequestRepository, declineRepo repo.ReviewDeclineRepository) *Stats {
+	return &Stats{
+		userRepo:    userRepo,
+		teamRepo:    teamRepo,
+		prRepo:      prRepo,
+		declineRepo: declineRepo,
+	}
+}
+
+// GetReviewerStats returns open review and decline counts for every member
+// of teamName.
+func (s *Stats) GetReviewerStats(ctx context.Context, teamName string) (*domain.TeamReviewerStats, error) {
+	exists, err := s.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.NewDomainError(domain.ErrNotFound, "team not found")
+	}
+	members, err := s.userRepo.GetByTeamName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.UserID)
+	}
+	openCounts, err := s.prRepo.GetOpenReviewCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	declineCounts, err := s.declineRepo.GetCountsByUsers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.TeamReviewerStats{TeamName: teamName, Reviewers: []*domain.ReviewerStats{}}
+	for _, member := range members {
+		entry := &domain.ReviewerStats{
+			UserID:           member.UserID,
+			Username:         member.Username,
+			IsActive:         member.IsActive,
+			OpenReviews:      openCounts[member.UserID],
+			DeclinesByReason: map[domain.DeclineReason]int{},
+		}
+		for reason, count := range declineCounts[member.UserID] {
+			entry.DeclinesByReason[reason] = count
+			entry.Declines += count
+		}
+		stats.Reviewers = append(stats.Reviewers, entry)
+	}
+	return stats, nil
+}
@@ -5,17 +5,25 @@ import (
 	"Avito/pkg/repo"
 	"context"
 	"fmt"
+	"log"
+	"slices"
 )
 
 type Team struct {
-	teamRepo repo.TeamRepository
-	userRepo repo.UserRepository
+	teamRepo      repo.TeamRepository
+	userRepo      repo.UserRepository
+	policyRepo    repo.TeamPolicyRepository
+	reviews       *PullRequest
+	defaultPolicy domain.TeamPolicy
 }
 
-func NewTeam(teamRepo repo.TeamRepository, userRepo repo.UserRepository) *Team {
+func NewTeam(teamRepo repo.TeamRepository, userRepo repo.UserRepository, policyRepo repo.TeamPolicyRepository, reviews *PullRequest, defaultPolicy domain.TeamPolicy) *Team {
 	return &Team{
-		teamRepo: teamRepo,
-		userRepo: userRepo,
+		teamRepo:      teamRepo,
+		userRepo:      userRepo,
+		policyRepo:    policyRepo,
+		reviews:       reviews,
+		defaultPolicy: defaultPolicy,
 	}
 }
 
@@ -24,24 +32,53 @@ func (t *Team) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team,
 	if err != nil {
 		return nil, err
 	}
+	if err := t.validateFallbackTeams(ctx, team.FallbackTeams); err != nil {
+		return nil, err
+	}
+	for _, m := range team.Members {
+		if err := validateSchedule(m.UserID, m.Timezone, m.WorkStart, m.WorkEnd); err != nil {
+			return nil, err
+		}
+	}
 	var createdMembers []*domain.TeamMember
 	if !exists {
+		if team.ReviewerStrategy == "" {
+			team.ReviewerStrategy = domain.StrategyLeastLoaded
+		}
 		if err := t.teamRepo.Create(ctx, team); err != nil {
 			return nil, err
 		}
 	} else {
+		if team.ReviewerStrategy != "" {
+			if err := t.teamRepo.SetReviewerStrategy(ctx, team.TeamName, team.ReviewerStrategy); err != nil {
+				return nil, err
+			}
+		} else {
+			existingTeam, err := t.teamRepo.GetByName(ctx, team.TeamName)
+			if err != nil {
+				return nil, err
+			}
+			team.ReviewerStrategy = existingTeam.ReviewerStrategy
+		}
 		usersOld, err := t.userRepo.GetByTeamName(ctx, team.TeamName)
 		if err != nil {
 			return nil, err
 		}
 		for _, user := range usersOld {
-			createdMembers = append(createdMembers, &domain.TeamMember{
-				UserID:   user.UserID,
-				Username: user.Username,
-				IsActive: user.IsActive,
-			})
+			createdMembers = append(createdMembers, newTeamMember(user))
 		}
 	}
+	if team.FallbackTeams != nil {
+		if err := t.teamRepo.SetFallbackTeams(ctx, team.TeamName, team.FallbackTeams); err != nil {
+			return nil, err
+		}
+	} else {
+		fallbackTeams, err := t.teamRepo.GetFallbackTeams(ctx, team.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		team.FallbackTeams = fallbackTeams
+	}
 	for _, m := range team.Members {
 		user := &domain.User{}
 		existingUser, err := t.userRepo.Exists(ctx, m.UserID)
@@ -54,6 +91,19 @@ func (t *Team) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team,
 				TeamName: &team.TeamName,
 				IsActive: &m.IsActive,
 			}
+			if m.Level != "" {
+				updatedUser.Level = &m.Level
+			}
+			if m.Timezone != "" {
+				updatedUser.Timezone = &m.Timezone
+			}
+			if m.WorkStart != "" {
+				updatedUser.WorkStart = &m.WorkStart
+				updatedUser.WorkEnd = &m.WorkEnd
+			}
+			if m.MaxOpenReviews != nil {
+				updatedUser.MaxOpenReviews = m.MaxOpenReviews
+			}
 			newUser, err := t.userRepo.Update(ctx, m.UserID, updatedUser)
 			if err != nil {
 				return nil, fmt.Errorf("failed to update user: %w", err)
@@ -62,27 +112,91 @@ func (t *Team) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team,
 			user.Username = newUser.Username
 			user.TeamName = newUser.TeamName
 			user.IsActive = newUser.IsActive
+			user.Level = newUser.Level
+			user.Timezone = newUser.Timezone
+			user.WorkStart = newUser.WorkStart
+			user.WorkEnd = newUser.WorkEnd
+			user.MaxOpenReviews = newUser.MaxOpenReviews
 		} else {
 			user.UserID = m.UserID
 			user.Username = m.Username
 			user.TeamName = team.TeamName
 			user.IsActive = m.IsActive
+			user.Level = m.Level
+			user.Timezone = m.Timezone
+			user.WorkStart = m.WorkStart
+			user.WorkEnd = m.WorkEnd
+			user.MaxOpenReviews = m.MaxOpenReviews
+			if m.Level == "" {
+				user.Level = domain.LevelMiddle
+			}
 			if err := t.userRepo.Create(ctx, user); err != nil {
 				return nil, fmt.Errorf("failed to add user: %e", err)
 			}
 		}
-		teamMembers := &domain.TeamMember{
-			UserID:   user.UserID,
-			Username: user.Username,
-			IsActive: user.IsActive,
+		if m.Skills != nil {
+			if err := t.userRepo.SetSkills(ctx, user.UserID, normalizeTags(m.Skills)); err != nil {
+				return nil, err
+			}
 		}
-		createdMembers = append(createdMembers, teamMembers)
+		createdMembers = append(createdMembers, newTeamMember(user))
+	}
+
+	if err := t.attachSkills(ctx, createdMembers); err != nil {
+		return nil, err
+	}
+
+	if _, err := t.reviews.TopUpTeam(ctx, team.TeamName); err != nil {
+		log.Printf("failed to top up reviews of team %s: %v", team.TeamName, err)
 	}
 
 	team.Members = createdMembers
 	return team, nil
 }
 
+// DeactivateMembers deactivates userIDs of teamName, or the whole team when
+// empty, and redistributes their open reviews in the same transaction.
+func (t *Team) DeactivateMembers(ctx context.Context, teamName string, userIDs []string) (*domain.TeamDeactivationReport, error) {
+	exists, err := t.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.NewDomainError(domain.ErrNotFound, "team not found")
+	}
+	members, err := t.userRepo.GetByTeamName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	isMember := make(map[string]bool, len(members))
+	for _, member := range members {
+		isMember[member.UserID] = true
+	}
+	for _, userID := range userIDs {
+		if !isMember[userID] {
+			return nil, domain.NewDomainError(domain.ErrNotFound, fmt.Sprintf("user %s is not a member of team %s", userID, teamName))
+		}
+	}
+
+	report := &domain.TeamDeactivationReport{
+		TeamName:    teamName,
+		Deactivated: make([]string, 0, len(members)),
+	}
+	for _, member := range members {
+		if len(userIDs) == 0 || slices.Contains(userIDs, member.UserID) {
+			report.Deactivated = append(report.Deactivated, member.UserID)
+		}
+	}
+	report.PullRequests, err = t.reviews.RedistributeReviews(ctx, report.Deactivated)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.reviews.ProcessReviewQueue(ctx, map[string]bool{teamName: true}); err != nil {
+		log.Printf("failed to process review queue after deactivating members of %s: %v", teamName, err)
+	}
+	return report, nil
+}
+
 func (t *Team) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
 	team, err := t.teamRepo.GetByName(ctx, teamName)
 	if err != nil {
@@ -94,14 +208,76 @@ func (t *Team) GetTeam(ctx context.Context, teamName string) (*domain.Team, erro
 	}
 	var members []*domain.TeamMember
 	for _, user := range users {
-		teamMembers := &domain.TeamMember{
-			UserID:   user.UserID,
-			Username: user.Username,
-			IsActive: user.IsActive,
-		}
-		members = append(members, teamMembers)
+		members = append(members, newTeamMember(user))
+	}
+
+	fallbackTeams, err := t.teamRepo.GetFallbackTeams(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.attachSkills(ctx, members); err != nil {
+		return nil, err
 	}
 
+	team.FallbackTeams = fallbackTeams
 	team.Members = members
 	return team, nil
 }
+
+func (t *Team) GetPolicy(ctx context.Context, teamName string) (*domain.TeamPolicy, error) {
+	exists, err := t.teamRepo.Exists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.NewDomainError(domain.ErrNotFound, "team not found")
+	}
+	return resolveTeamPolicy(ctx, t.policyRepo, t.defaultPolicy, teamName)
+}
+
+func (t *Team) SetPolicy(ctx context.Context, teamName string, patch *domain.TeamPolicyUpdate) (*domain.TeamPolicy, error) {
+	policy, err := t.GetPolicy(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	oldLimit := policy.MaxOpenReviews
+	if err := applyTeamPolicyUpdate(policy, patch); err != nil {
+		return nil, err
+	}
+	if err := t.policyRepo.Upsert(ctx, policy); err != nil {
+		return nil, err
+	}
+	if oldLimit > 0 && (policy.MaxOpenReviews == 0 || policy.MaxOpenReviews > oldLimit) {
+		if _, err := t.reviews.ProcessReviewQueue(ctx, map[string]bool{teamName: true}); err != nil {
+			log.Printf("failed to process review queue after raising max_open_reviews of %s: %v", teamName, err)
+		}
+	}
+	return policy, nil
+}
+
+func (t *Team) validateFallbackTeams(ctx context.Context, fallbackTeams []string) error {
+	for _, fallbackTeam := range fallbackTeams {
+		exists, err := t.teamRepo.Exists(ctx, fallbackTeam)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return domain.NewDomainError(domain.ErrNotFound, fmt.Sprintf("fallback team %s not found", fallbackTeam))
+		}
+	}
+	return nil
+}
+
+func newTeamMember(user *domain.User) *domain.TeamMember {
+	return &domain.TeamMember{
+		UserID:         user.UserID,
+		Username:       user.Username,
+		IsActive:       user.IsActive,
+		Level:          user.Level,
+		Timezone:       user.Timezone,
+		WorkStart:      user.WorkStart,
+		WorkEnd:        user.WorkEnd,
+		MaxOpenReviews: user.MaxOpenReviews,
+	}
+}
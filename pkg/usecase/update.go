@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"Avito/pkg/domain"
+	"context"
+	"strings"
+)
+
+// GetPullRequest returns prID with its labels, reviewers and their latest
+// verdicts, plus the merge override when it was force-merged.
+func (p *PullRequest) GetPullRequest(ctx context.Context, prID string) (*domain.PullRequest, *domain.MergeOverride, error) {
+	pr, err := p.loadPullRequest(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pr.Status != domain.PRStatusMerged {
+		return pr, nil, nil
+	}
+	override, err := p.findMergeOverride(ctx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pr, override, nil
+}
+
+// UpdatePullRequest changes the name, description, labels or metadata of a
+// PR that is not merged yet. Reviewers are left as they are.
+func (p *PullRequest) UpdatePullRequest(ctx context.Context, prID string, patch *domain.PullRequestUpdate) (*domain.PullRequest, error) {
+	if patch.PullRequestName != nil {
+		name := strings.TrimSpace(*patch.PullRequestName)
+		if name == "" {
+			return nil, domain.NewDomainError(domain.ErrInvalidUpdate, "pull_request_name must not be empty")
+		}
+		patch.PullRequestName = &name
+	}
+	if patch.Labels != nil {
+		labels := normalizeTags(*patch.Labels)
+		patch.Labels = &labels
+	}
+	for key := range patch.Metadata {
+		if strings.TrimSpace(key) == "" {
+			return nil, domain.NewDomainError(domain.ErrInvalidUpdate, "metadata keys must not be empty")
+		}
+	}
+
+	pr, err := p.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Status == domain.PRStatusMerged {
+		return nil, domain.NewDomainError(domain.ErrPRMerged, "cannot update merged PR")
+	}
+	if err := p.prRepo.Update(ctx, prID, patch); err != nil {
+		return nil, err
+	}
+	return p.loadPullRequest(ctx, prID)
+}
+
+func (p *PullRequest) loadPullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	pr, err := p.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := p.prRepo.GetLabels(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	reviewers, err := p.prRepo.GetReviewers(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	pr.Labels = labels
+	pr.AssignedReviewers = reviewers
+	if err := p.attachReviewerStates(ctx, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"Avito/pkg/config"
+	"Avito/pkg/domain"
 	"Avito/pkg/repo/pg"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -11,20 +12,55 @@ type Cases struct {
 	User        *User
 	Team        *Team
 	PullRequest *PullRequest
+	Ownership   *Ownership
+	Rules       *ReviewerRules
+	Rebalancer  *Rebalancer
+	Absences    *Absences
+	Stats       *Stats
 }
 
 func Setup(cfg *config.Config, pool *pgxpool.Pool) *Cases {
 	userRepo := pg.NewUser(pool)
 	teamRepo := pg.NewTeam(pool)
 	pullRequestRepo := pg.NewPullRequest(pool)
+	assignmentTraceRepo := pg.NewAssignmentTrace(pool)
+	ownershipRepo := pg.NewOwnership(pool)
+	teamPolicyRepo := pg.NewTeamPolicy(pool)
+	reviewerRuleRepo := pg.NewReviewerRule(pool)
+	reassignmentRepo := pg.NewReassignment(pool)
+	absenceRepo := pg.NewAbsence(pool)
+	reviewDeclineRepo := pg.NewReviewDecline(pool)
+	reviewRepo := pg.NewReview(pool)
+	defaultPolicy := domain.TeamPolicy{
+		MinReviewers:      cfg.MinCountReviewers,
+		MaxReviewers:      cfg.MaxCountReviewers,
+		AllowCrossTeam:    true,
+		WorkingHoursMode:  domain.WorkingHoursSoft,
+		MaxOpenReviews:    cfg.MaxOpenReviews,
+		RequiredApprovals: cfg.RequiredApprovals,
+	}
 
-	userCase := NewUser(userRepo)
-	teamCase := NewTeam(teamRepo, userRepo)
-	pullRequestCase := NewPullRequest(pullRequestRepo, userRepo, cfg.MaxCountReviewers)
+	pullRequestCase := NewPullRequest(pullRequestRepo, userRepo, teamRepo, assignmentTraceRepo, ownershipRepo, teamPolicyRepo, reviewerRuleRepo, reviewRepo, TimeSeed, defaultPolicy, cfg.AdminTokens)
+	userCase := NewUser(userRepo, pullRequestCase)
+	teamCase := NewTeam(teamRepo, userRepo, teamPolicyRepo, pullRequestCase, defaultPolicy)
+	ownershipCase := NewOwnership(ownershipRepo)
+	reviewerRulesCase := NewReviewerRules(reviewerRuleRepo, userRepo)
+	absencesCase := NewAbsences(absenceRepo, userRepo, pullRequestCase, cfg.Absence.CheckInterval)
+	statsCase := NewStats(userRepo, teamRepo, pullRequestRepo, reviewDeclineRepo)
+	rebalancerCase := NewRebalancer(pullRequestRepo, userRepo, teamRepo, teamPolicyRepo, reassignmentRepo, pullRequestCase, defaultPolicy, RebalanceSettings{
+		Interval: cfg.Rebalance.Interval,
+		MaxAge:   cfg.Rebalance.MaxAge,
+		MaxSkew:  cfg.Rebalance.MaxSkew,
+	})
 
 	return &Cases{
 		User:        userCase,
 		Team:        teamCase,
 		PullRequest: pullRequestCase,
+		Ownership:   ownershipCase,
+		Rules:       reviewerRulesCase,
+		Rebalancer:  rebalancerCase,
+		Absences:    absencesCase,
+		Stats:       statsCase,
 	}
 }
@@ -4,31 +4,61 @@ import (
 	"Avito/pkg/domain"
 	"Avito/pkg/repo"
 	"context"
+	"log"
 )
 
 type User struct {
 	userRepo repo.UserRepository
+	reviews  *PullRequest
 }
 
-func NewUser(userRepo repo.UserRepository) *User {
+func NewUser(userRepo repo.UserRepository, reviews *PullRequest) *User {
 	return &User{
 		userRepo: userRepo,
+		reviews:  reviews,
 	}
 }
 
-func (u *User) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+// SetIsActive flips the user's activity flag. With reassignOpenReviews a
+// deactivated user's open reviews are handed off, and the affected PRs are
+// returned.
+func (u *User) SetIsActive(ctx context.Context, userID string, isActive bool, reassignOpenReviews bool) (*domain.User, []*domain.ReviewHandoff, error) {
 	exists, err := u.userRepo.Exists(ctx, userID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !exists {
-		return nil, domain.NewDomainError(domain.ErrNotFound, "user not found")
+		return nil, nil, domain.NewDomainError(domain.ErrNotFound, "user not found")
+	}
+	if !isActive && reassignOpenReviews {
+		return u.deactivate(ctx, userID)
 	}
 	user, err := u.userRepo.SetIsActive(ctx, userID, isActive)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return user, nil
+	if isActive {
+		if _, err := u.reviews.TopUpTeam(ctx, user.TeamName); err != nil {
+			log.Printf("failed to top up reviews of team %s after activating %s: %v", user.TeamName, userID, err)
+		}
+	}
+	return user, nil, nil
+}
+
+// deactivate deactivates userID together with handing off their open reviews.
+func (u *User) deactivate(ctx context.Context, userID string) (*domain.User, []*domain.ReviewHandoff, error) {
+	handoffs, err := u.reviews.DeactivateReviewer(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := u.reviews.ProcessReviewQueue(ctx, map[string]bool{user.TeamName: true}); err != nil {
+		log.Printf("failed to process review queue after deactivating %s: %v", userID, err)
+	}
+	return user, handoffs, nil
 }
 
 func (u *User) GetUser(ctx context.Context, userID string) (*domain.User, error) {
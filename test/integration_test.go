@@ -3,7 +3,9 @@ package test
 import (
 	"Avito/pkg/domain"
 	"Avito/pkg/repo/pg"
+	"Avito/pkg/usecase"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -38,10 +40,18 @@ func TestMain(m *testing.M) {
 func cleanupDB(t *testing.T) {
 	t.Helper()
 	queries := []string{
+		"DELETE FROM assignment_traces",
+		"DELETE FROM reassignments",
+		"DELETE FROM user_absences",
+		"DELETE FROM review_declines",
+		"DELETE FROM review_verdicts",
+		"DELETE FROM merge_overrides",
 		"DELETE FROM pr_reviewers",
 		"DELETE FROM pull_requests",
 		"DELETE FROM users",
 		"DELETE FROM teams",
+		"DELETE FROM ownership_rules",
+		"DELETE FROM reviewer_rules",
 	}
 
 	for _, query := range queries {
@@ -102,6 +112,82 @@ func TestTeamRepository(t *testing.T) {
 			t.Error("Expected error when getting non-existing team")
 		}
 	})
+
+	t.Run("Default And Updated Reviewer Strategy", func(t *testing.T) {
+		team, err := teamRepo.GetByName(ctx, "backend-team")
+		if err != nil {
+			t.Fatalf("Failed to get team: %v", err)
+		}
+		if team.ReviewerStrategy != domain.StrategyLeastLoaded {
+			t.Errorf("Expected strategy %s, got %s", domain.StrategyLeastLoaded, team.ReviewerStrategy)
+		}
+
+		err = teamRepo.SetReviewerStrategy(ctx, "backend-team", domain.StrategyRoundRobin)
+		if err != nil {
+			t.Fatalf("Failed to set reviewer strategy: %v", err)
+		}
+		team, err = teamRepo.GetByName(ctx, "backend-team")
+		if err != nil {
+			t.Fatalf("Failed to get team: %v", err)
+		}
+		if team.ReviewerStrategy != domain.StrategyRoundRobin {
+			t.Errorf("Expected strategy %s, got %s", domain.StrategyRoundRobin, team.ReviewerStrategy)
+		}
+	})
+
+	t.Run("Fallback Teams", func(t *testing.T) {
+		for _, teamName := range []string{"platform-team", "infra-team"} {
+			if err := teamRepo.Create(ctx, &domain.Team{TeamName: teamName}); err != nil {
+				t.Fatalf("Failed to create team %s: %v", teamName, err)
+			}
+		}
+		err := teamRepo.SetFallbackTeams(ctx, "backend-team", []string{"platform-team", "infra-team"})
+		if err != nil {
+			t.Fatalf("Failed to set fallback teams: %v", err)
+		}
+		fallbackTeams, err := teamRepo.GetFallbackTeams(ctx, "backend-team")
+		if err != nil {
+			t.Fatalf("Failed to get fallback teams: %v", err)
+		}
+		if len(fallbackTeams) != 2 || fallbackTeams[0] != "platform-team" || fallbackTeams[1] != "infra-team" {
+			t.Errorf("Expected [platform-team infra-team], got %v", fallbackTeams)
+		}
+
+		err = teamRepo.SetFallbackTeams(ctx, "backend-team", []string{"infra-team"})
+		if err != nil {
+			t.Fatalf("Failed to replace fallback teams: %v", err)
+		}
+		fallbackTeams, err = teamRepo.GetFallbackTeams(ctx, "backend-team")
+		if err != nil {
+			t.Fatalf("Failed to get fallback teams: %v", err)
+		}
+		if len(fallbackTeams) != 1 || fallbackTeams[0] != "infra-team" {
+			t.Errorf("Expected [infra-team], got %v", fallbackTeams)
+		}
+	})
+
+	t.Run("Round Robin Cursor", func(t *testing.T) {
+		cursor, err := teamRepo.GetRoundRobinCursor(ctx, "backend-team")
+		if err != nil {
+			t.Fatalf("Failed to get cursor: %v", err)
+		}
+		if cursor != "" {
+			t.Errorf("Expected empty cursor, got %s", cursor)
+		}
+
+		for _, userID := range []string{"user-1", "user-2"} {
+			if err := teamRepo.SetRoundRobinCursor(ctx, "backend-team", userID); err != nil {
+				t.Fatalf("Failed to set cursor: %v", err)
+			}
+		}
+		cursor, err = teamRepo.GetRoundRobinCursor(ctx, "backend-team")
+		if err != nil {
+			t.Fatalf("Failed to get cursor: %v", err)
+		}
+		if cursor != "user-2" {
+			t.Errorf("Expected cursor user-2, got %s", cursor)
+		}
+	})
 }
 
 func TestUserRepository(t *testing.T) {
@@ -312,6 +398,85 @@ func TestUserRepository(t *testing.T) {
 			t.Error("Expected error when updating non-existing user")
 		}
 	})
+
+	t.Run("Default And Updated Level", func(t *testing.T) {
+		user, err := userRepo.GetByID(ctx, "user-1")
+		if err != nil {
+			t.Fatalf("Failed to get user: %v", err)
+		}
+		if user.Level != domain.LevelMiddle {
+			t.Errorf("Expected default level %s, got %s", domain.LevelMiddle, user.Level)
+		}
+
+		level := domain.LevelSenior
+		updated, err := userRepo.Update(ctx, "user-1", &domain.UserUpdate{Level: &level})
+		if err != nil {
+			t.Fatalf("Failed to update level: %v", err)
+		}
+		if updated.Level != domain.LevelSenior {
+			t.Errorf("Expected level %s, got %s", domain.LevelSenior, updated.Level)
+		}
+	})
+
+	t.Run("Update Working Hours", func(t *testing.T) {
+		timezone, workStart, workEnd := "Asia/Yerevan", "09:00", "18:00"
+		updated, err := userRepo.Update(ctx, "user-1", &domain.UserUpdate{Timezone: &timezone, WorkStart: &workStart, WorkEnd: &workEnd})
+		if err != nil {
+			t.Fatalf("Failed to update working hours: %v", err)
+		}
+		if updated.Timezone != timezone || updated.WorkStart != workStart || updated.WorkEnd != workEnd {
+			t.Errorf("Expected %s %s-%s, got %s %s-%s", timezone, workStart, workEnd, updated.Timezone, updated.WorkStart, updated.WorkEnd)
+		}
+	})
+
+	t.Run("Update Max Open Reviews", func(t *testing.T) {
+		user, err := userRepo.GetByID(ctx, "user-1")
+		if err != nil {
+			t.Fatalf("Failed to get user: %v", err)
+		}
+		if user.MaxOpenReviews != nil {
+			t.Errorf("Expected no personal limit, got %d", *user.MaxOpenReviews)
+		}
+
+		limit := 3
+		updated, err := userRepo.Update(ctx, "user-1", &domain.UserUpdate{MaxOpenReviews: &limit})
+		if err != nil {
+			t.Fatalf("Failed to update max open reviews: %v", err)
+		}
+		if updated.MaxOpenReviews == nil || *updated.MaxOpenReviews != limit {
+			t.Errorf("Expected max open reviews %d, got %v", limit, updated.MaxOpenReviews)
+		}
+	})
+
+	t.Run("Set and Get Skills", func(t *testing.T) {
+		if err := userRepo.SetSkills(ctx, "user-1", []string{"go", "postgres"}); err != nil {
+			t.Fatalf("Failed to set skills: %v", err)
+		}
+		if err := userRepo.SetSkills(ctx, "user-1", []string{"go"}); err != nil {
+			t.Fatalf("Failed to replace skills: %v", err)
+		}
+
+		skills, err := userRepo.GetSkills(ctx, []string{"user-1", "non-existing-user-id"})
+		if err != nil {
+			t.Fatalf("Failed to get skills: %v", err)
+		}
+		if len(skills["user-1"]) != 1 || skills["user-1"][0] != "go" {
+			t.Errorf("Expected skills [go], got %v", skills["user-1"])
+		}
+		if len(skills["non-existing-user-id"]) != 0 {
+			t.Errorf("Expected no skills for unknown user, got %v", skills["non-existing-user-id"])
+		}
+	})
+
+	t.Run("Get By IDs", func(t *testing.T) {
+		users, err := userRepo.GetByIDs(ctx, []string{"user-1", "non-existing-user-id"})
+		if err != nil {
+			t.Fatalf("Failed to get users by IDs: %v", err)
+		}
+		if len(users) != 1 || users[0].UserID != "user-1" {
+			t.Errorf("Expected [user-1], got %v", users)
+		}
+	})
 }
 
 func TestPullRequestRepository(t *testing.T) {
@@ -403,6 +568,214 @@ func TestPullRequestRepository(t *testing.T) {
 		if mergedPR.MergedAt == nil {
 			t.Error("Expected merged_at to be set")
 		}
+
+		err = prRepo.SetMerged(ctx, "pr-1")
+		expectDomainError(t, err, domain.ErrPRMerged)
+	})
+
+	t.Run("Close and Reopen Pull Request", func(t *testing.T) {
+		pr := &domain.PullRequest{
+			PullRequestID:   "pr-closed",
+			PullRequestName: "Abandoned idea",
+			AuthorID:        "author-1",
+			Status:          domain.PRStatusOpen,
+			CreatedAt:       time.Now(),
+		}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create pull request: %v", err)
+		}
+
+		if err := prRepo.SetClosed(ctx, "pr-closed"); err != nil {
+			t.Fatalf("Failed to close pull request: %v", err)
+		}
+		closedPR, err := prRepo.GetByID(ctx, "pr-closed")
+		if err != nil {
+			t.Fatalf("Failed to get closed PR: %v", err)
+		}
+		if closedPR.Status != domain.PRStatusClosed {
+			t.Errorf("Expected status %s, got %s", domain.PRStatusClosed, closedPR.Status)
+		}
+		if closedPR.ClosedAt == nil {
+			t.Error("Expected closed_at to be set")
+		}
+
+		err = prRepo.SetClosed(ctx, "pr-closed")
+		expectDomainError(t, err, domain.ErrPRClosed)
+		err = prRepo.SetMerged(ctx, "pr-closed")
+		expectDomainError(t, err, domain.ErrPRClosed)
+
+		if err := prRepo.SetReopened(ctx, "pr-closed", nil); err != nil {
+			t.Fatalf("Failed to reopen pull request: %v", err)
+		}
+		reopenedPR, err := prRepo.GetByID(ctx, "pr-closed")
+		if err != nil {
+			t.Fatalf("Failed to get reopened PR: %v", err)
+		}
+		if reopenedPR.Status != domain.PRStatusOpen {
+			t.Errorf("Expected status %s, got %s", domain.PRStatusOpen, reopenedPR.Status)
+		}
+		if reopenedPR.ClosedAt != nil {
+			t.Error("Expected closed_at to be cleared")
+		}
+
+		err = prRepo.SetReopened(ctx, "pr-closed", nil)
+		expectDomainError(t, err, domain.ErrNotFound)
+
+		if err := prRepo.SetClosed(ctx, "pr-closed"); err != nil {
+			t.Fatalf("Failed to close pull request: %v", err)
+		}
+		if err := prRepo.SetClosed(ctx, "pr-missing"); err == nil {
+			t.Error("Expected error when closing non-existing PR")
+		}
+	})
+
+	t.Run("Mark Draft Ready", func(t *testing.T) {
+		pr := &domain.PullRequest{
+			PullRequestID:   "pr-draft",
+			PullRequestName: "Work in progress",
+			AuthorID:        "author-1",
+			Status:          domain.PRStatusDraft,
+			CreatedAt:       time.Now(),
+		}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create draft: %v", err)
+		}
+
+		if err := prRepo.MarkReady(ctx, "pr-draft", []string{"reviewer-1"}, true, nil); err != nil {
+			t.Fatalf("Failed to mark draft ready: %v", err)
+		}
+		readyPR, err := prRepo.GetByID(ctx, "pr-draft")
+		if err != nil {
+			t.Fatalf("Failed to get ready PR: %v", err)
+		}
+		if readyPR.Status != domain.PRStatusOpen || !readyPR.UnderReviewed {
+			t.Errorf("Expected OPEN under-reviewed PR, got %s (under_reviewed=%v)", readyPR.Status, readyPR.UnderReviewed)
+		}
+		reviewers, err := prRepo.GetReviewers(ctx, "pr-draft")
+		if err != nil {
+			t.Fatalf("Failed to get reviewers: %v", err)
+		}
+		if len(reviewers) != 1 || reviewers[0] != "reviewer-1" {
+			t.Errorf("Expected [reviewer-1], got %v", reviewers)
+		}
+
+		if err := prRepo.MarkReady(ctx, "pr-draft", nil, false, nil); err == nil {
+			t.Error("Expected error when marking a non-draft PR ready")
+		}
+		if err := prRepo.SetMerged(ctx, "pr-draft"); err != nil {
+			t.Fatalf("Failed to merge PR: %v", err)
+		}
+	})
+
+	t.Run("Force Merge With Override", func(t *testing.T) {
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-forced",
+			PullRequestName:   "Hotfix",
+			AuthorID:          "author-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"reviewer-1"},
+			CreatedAt:         time.Now(),
+		}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create pull request: %v", err)
+		}
+		if _, err := prRepo.GetMergeOverride(ctx, "pr-forced"); err == nil {
+			t.Error("Expected error when PR has no merge override")
+		}
+
+		override := &domain.MergeOverride{
+			PullRequestID:      "pr-forced",
+			UserID:             "author-1",
+			Reason:             "production incident",
+			RequiredApprovals:  1,
+			ApprovedBy:         []string{},
+			ChangesRequestedBy: []string{"reviewer-1"},
+			CreatedAt:          time.Now(),
+		}
+		if err := prRepo.SetForceMerged(ctx, override); err != nil {
+			t.Fatalf("Failed to force merge PR: %v", err)
+		}
+		if override.ID == 0 {
+			t.Error("Expected override ID to be set")
+		}
+		mergedPR, err := prRepo.GetByID(ctx, "pr-forced")
+		if err != nil {
+			t.Fatalf("Failed to get merged PR: %v", err)
+		}
+		if mergedPR.Status != domain.PRStatusMerged || mergedPR.MergedAt == nil {
+			t.Errorf("Expected MERGED PR with merged_at, got %s", mergedPR.Status)
+		}
+
+		retrieved, err := prRepo.GetMergeOverride(ctx, "pr-forced")
+		if err != nil {
+			t.Fatalf("Failed to get merge override: %v", err)
+		}
+		if retrieved.UserID != "author-1" || retrieved.Reason != "production incident" {
+			t.Errorf("Expected override by author-1 for production incident, got %s: %s", retrieved.UserID, retrieved.Reason)
+		}
+		if len(retrieved.ChangesRequestedBy) != 1 || retrieved.ChangesRequestedBy[0] != "reviewer-1" {
+			t.Errorf("Expected changes requested by [reviewer-1], got %v", retrieved.ChangesRequestedBy)
+		}
+
+		err = prRepo.SetForceMerged(ctx, override)
+		expectDomainError(t, err, domain.ErrPRMerged)
+	})
+
+	t.Run("Update Pull Request", func(t *testing.T) {
+		pr := &domain.PullRequest{
+			PullRequestID:   "pr-update",
+			PullRequestName: "Initial name",
+			AuthorID:        "author-1",
+			Status:          domain.PRStatusOpen,
+			Labels:          []string{"backend"},
+			CreatedAt:       time.Now(),
+		}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create pull request: %v", err)
+		}
+
+		name, description, ticket := "Renamed", "Adds search filters", "SRCH-12"
+		labels := []string{"search", "frontend"}
+		err := prRepo.Update(ctx, "pr-update", &domain.PullRequestUpdate{
+			PullRequestName: &name,
+			Description:     &description,
+			Labels:          &labels,
+			Metadata:        map[string]*string{"ticket": &ticket, "env": &description},
+		})
+		if err != nil {
+			t.Fatalf("Failed to update pull request: %v", err)
+		}
+		err = prRepo.Update(ctx, "pr-update", &domain.PullRequestUpdate{
+			Metadata: map[string]*string{"env": nil},
+		})
+		if err != nil {
+			t.Fatalf("Failed to remove metadata key: %v", err)
+		}
+
+		updatedPR, err := prRepo.GetByID(ctx, "pr-update")
+		if err != nil {
+			t.Fatalf("Failed to get updated PR: %v", err)
+		}
+		if updatedPR.PullRequestName != "Renamed" || updatedPR.Description != "Adds search filters" {
+			t.Errorf("Expected renamed PR with description, got %q and %q", updatedPR.PullRequestName, updatedPR.Description)
+		}
+		if len(updatedPR.Metadata) != 1 || updatedPR.Metadata["ticket"] != "SRCH-12" {
+			t.Errorf("Expected metadata {ticket: SRCH-12}, got %v", updatedPR.Metadata)
+		}
+		updatedLabels, err := prRepo.GetLabels(ctx, "pr-update")
+		if err != nil {
+			t.Fatalf("Failed to get labels: %v", err)
+		}
+		if len(updatedLabels) != 2 {
+			t.Errorf("Expected 2 labels, got %v", updatedLabels)
+		}
+
+		if err := prRepo.Update(ctx, "pr-1", &domain.PullRequestUpdate{PullRequestName: &name}); err == nil {
+			t.Error("Expected error when updating merged PR")
+		}
+		if err := prRepo.Update(ctx, "pr-missing", &domain.PullRequestUpdate{PullRequestName: &name}); err == nil {
+			t.Error("Expected error when updating non-existing PR")
+		}
 	})
 
 	t.Run("Check PR Exists", func(t *testing.T) {
@@ -579,86 +952,2010 @@ func TestPullRequestRepository(t *testing.T) {
 			t.Errorf("Expected 0 PRs for non-existing IDs, got %d", len(prs))
 		}
 	})
-}
 
-func TestComplexScenario(t *testing.T) {
-	cleanupDB(t)
-	teamRepo := pg.NewTeam(testPool)
-	userRepo := pg.NewUser(testPool)
-	prRepo := pg.NewPullRequest(testPool)
-	t.Run("Full Workflow", func(t *testing.T) {
-		team := &domain.Team{TeamName: "fullstack-team"}
-		err := teamRepo.Create(ctx, team)
+	t.Run("Get Open Under Reviewed", func(t *testing.T) {
+		underReviewed := true
+		for _, prID := range []string{"pr-1", "pr-2"} {
+			if err := prRepo.ApplyReviewerChange(ctx, &domain.ReviewerChange{PullRequestID: prID, UnderReviewed: &underReviewed}); err != nil {
+				t.Fatalf("Failed to set under-reviewed flag: %v", err)
+			}
+		}
+
+		prs, err := prRepo.GetOpenUnderReviewed(ctx)
 		if err != nil {
-			t.Fatalf("Failed to create team: %v", err)
+			t.Fatalf("Failed to get under-reviewed PRs: %v", err)
 		}
-		users := []*domain.User{
-			{UserID: "fs-1", Username: "john", TeamName: "fullstack-team", IsActive: true},
-			{UserID: "fs-2", Username: "jane", TeamName: "fullstack-team", IsActive: true},
-			{UserID: "fs-3", Username: "jack", TeamName: "fullstack-team", IsActive: false},
+		if len(prs) != 1 || prs[0].PullRequestID != "pr-2" {
+			t.Errorf("Expected only open pr-2, got %v", prs)
+		}
+	})
+
+	t.Run("Get Open Review Counts", func(t *testing.T) {
+		counts, err := prRepo.GetOpenReviewCounts(ctx, []string{"reviewer-1", "reviewer-2", "author-1"})
+		if err != nil {
+			t.Fatalf("Failed to get open review counts: %v", err)
 		}
 
-		for _, user := range users {
-			err := userRepo.Create(ctx, user)
-			if err != nil {
-				t.Fatalf("Failed to create user %s: %v", user.UserID, err)
-			}
+		if counts["reviewer-1"] != 2 {
+			t.Errorf("Expected 2 open reviews for reviewer-1, got %d", counts["reviewer-1"])
 		}
+		if counts["reviewer-2"] != 1 {
+			t.Errorf("Expected 1 open review for reviewer-2, got %d", counts["reviewer-2"])
+		}
+		if counts["author-1"] != 0 {
+			t.Errorf("Expected 0 open reviews for author-1, got %d", counts["author-1"])
+		}
+	})
+
+	t.Run("Get Open Assignments", func(t *testing.T) {
+		assignments, err := prRepo.GetOpenAssignments(ctx, []string{"reviewer-1"}, 0, false)
+		if err != nil {
+			t.Fatalf("Failed to get open assignments: %v", err)
+		}
+		if len(assignments) != 2 {
+			t.Fatalf("Expected 2 open assignments, got %d", len(assignments))
+		}
+		if assignments[0].AuthorID != "author-1" {
+			t.Errorf("Expected author author-1, got %s", assignments[0].AuthorID)
+		}
+
+		_, err = testPool.Exec(ctx, "UPDATE pr_reviewers SET assigned_at = NOW() - INTERVAL '2 days' WHERE pull_request_id = $1 AND user_id = $2", assignments[1].PullRequestID, "reviewer-1")
+		if err != nil {
+			t.Fatalf("Failed to backdate assignment: %v", err)
+		}
+		recent, err := prRepo.GetOpenAssignments(ctx, []string{"reviewer-1"}, 24*time.Hour, false)
+		if err != nil {
+			t.Fatalf("Failed to get open assignments: %v", err)
+		}
+		if len(recent) != 1 || recent[0].PullRequestID != assignments[0].PullRequestID {
+			t.Errorf("Expected only %s within max age, got %v", assignments[0].PullRequestID, recent)
+		}
+	})
+
+	t.Run("Create With Labels", func(t *testing.T) {
 		pr := &domain.PullRequest{
-			PullRequestID:     "pr-complex-1",
-			PullRequestName:   "Refactor database layer",
-			AuthorID:          "fs-1",
+			PullRequestID:     "pr-labels",
+			PullRequestName:   "Labeled PR",
+			AuthorID:          "author-1",
 			Status:            domain.PRStatusOpen,
-			AssignedReviewers: []string{"fs-2"},
+			Labels:            []string{"postgres", "go"},
+			AssignedReviewers: []string{},
 			CreatedAt:         time.Now(),
 		}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create PR: %v", err)
+		}
 
-		err = prRepo.Create(ctx, pr)
+		labels, err := prRepo.GetLabels(ctx, "pr-labels")
 		if err != nil {
-			t.Fatalf("Failed to create PR: %v", err)
+			t.Fatalf("Failed to get labels: %v", err)
 		}
-		teamExists, err := teamRepo.Exists(ctx, "fullstack-team")
-		if err != nil || !teamExists {
-			t.Error("Team should exist")
+		if len(labels) != 2 || labels[0] != "go" || labels[1] != "postgres" {
+			t.Errorf("Expected labels [go postgres], got %v", labels)
 		}
+	})
 
-		teamUsers, err := userRepo.GetByTeamName(ctx, "fullstack-team")
+	t.Run("Get Open By Author Team", func(t *testing.T) {
+		prs, err := prRepo.GetOpenByAuthorTeam(ctx, "dev-team")
 		if err != nil {
-			t.Fatalf("Failed to get team users: %v", err)
+			t.Fatalf("Failed to get open PRs by team: %v", err)
 		}
-		if len(teamUsers) != 3 {
-			t.Errorf("Expected 3 users in team, got %d", len(teamUsers))
+		found := false
+		for _, pr := range prs {
+			if pr.Status != domain.PRStatusOpen {
+				t.Errorf("Expected only OPEN PRs, got %s with status %s", pr.PullRequestID, pr.Status)
+			}
+			if pr.PullRequestID == "pr-labels" {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("Expected pr-labels among open PRs of dev-team")
 		}
 
-		retrievedPR, err := prRepo.GetByID(ctx, "pr-complex-1")
+		prs, err = prRepo.GetOpenByAuthorTeam(ctx, "non-existing-team")
 		if err != nil {
-			t.Fatalf("Failed to get PR: %v", err)
+			t.Fatalf("Failed to get open PRs by team: %v", err)
 		}
-		if retrievedPR.Status != domain.PRStatusOpen {
-			t.Errorf("Expected PR status OPEN, got %s", retrievedPR.Status)
+		if len(prs) != 0 {
+			t.Errorf("Expected no PRs for unknown team, got %d", len(prs))
 		}
-		_, err = userRepo.SetIsActive(ctx, "fs-2", false)
+	})
+
+	t.Run("Apply Redistribution", func(t *testing.T) {
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-swaps",
+			PullRequestName:   "Swap reviewers",
+			AuthorID:          "author-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"reviewer-1", "reviewer-2"},
+			CreatedAt:         time.Now(),
+		}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create PR: %v", err)
+		}
+
+		err := prRepo.ApplyRedistribution(ctx, &domain.ReviewRedistribution{
+			Swaps: []*domain.ReviewerSwap{
+				{PullRequestID: "pr-swaps", FromUserID: "reviewer-1"},
+				{PullRequestID: "pr-swaps", FromUserID: "reviewer-2", ToUserID: "reviewer-1"},
+			},
+			NowUnderReviewed: []string{"pr-swaps", "pr-labels"},
+		})
 		if err != nil {
-			t.Fatalf("Failed to deactivate user: %v", err)
+			t.Fatalf("Failed to apply redistribution: %v", err)
 		}
-		activeUsers, err := userRepo.GetActiveByTeamExcluding(ctx, "fullstack-team", []string{})
+		reviewers, err := prRepo.GetReviewersByPRs(ctx, []string{"pr-swaps", "pr-labels"})
 		if err != nil {
-			t.Fatalf("Failed to get active users: %v", err)
+			t.Fatalf("Failed to get reviewers by PRs: %v", err)
 		}
-		if len(activeUsers) != 1 {
-			t.Errorf("Expected 1 active user, got %d", len(activeUsers))
+		if len(reviewers["pr-swaps"]) != 1 || reviewers["pr-swaps"][0] != "reviewer-1" {
+			t.Errorf("Expected reviewers [reviewer-1], got %v", reviewers["pr-swaps"])
 		}
-		err = prRepo.SetMerged(ctx, "pr-complex-1")
-		if err != nil {
-			t.Fatalf("Failed to merge PR: %v", err)
+		if len(reviewers["pr-labels"]) != 0 {
+			t.Errorf("Expected no reviewers for pr-labels, got %v", reviewers["pr-labels"])
 		}
 
-		finalPR, err := prRepo.GetByID(ctx, "pr-complex-1")
+		prs, err := prRepo.GetByIDs(ctx, []string{"pr-swaps", "pr-labels"})
 		if err != nil {
-			t.Fatalf("Failed to get final PR: %v", err)
+			t.Fatalf("Failed to get PRs: %v", err)
 		}
-		if finalPR.Status != domain.PRStatusMerged {
+		for _, pr := range prs {
+			if !pr.UnderReviewed {
+				t.Errorf("Expected %s to be under-reviewed", pr.PullRequestID)
+			}
+		}
+	})
+}
+
+func TestAssignmentTraceRepository(t *testing.T) {
+	cleanupDB(t)
+	teamRepo := pg.NewTeam(testPool)
+	userRepo := pg.NewUser(testPool)
+	prRepo := pg.NewPullRequest(testPool)
+	traceRepo := pg.NewAssignmentTrace(testPool)
+	err := teamRepo.Create(ctx, &domain.Team{TeamName: "trace-team"})
+	if err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+	users := []*domain.User{
+		{UserID: "tr-1", Username: "author", TeamName: "trace-team", IsActive: true},
+		{UserID: "tr-2", Username: "reviewer", TeamName: "trace-team", IsActive: true},
+		{UserID: "tr-3", Username: "inactive", TeamName: "trace-team", IsActive: false},
+	}
+	for _, user := range users {
+		if err := userRepo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user %s: %v", user.UserID, err)
+		}
+	}
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-trace-1",
+		PullRequestName:   "Trace me",
+		AuthorID:          "tr-1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"tr-2"},
+		CreatedAt:         time.Now(),
+	}
+	if err := prRepo.Create(ctx, pr); err != nil {
+		t.Fatalf("Failed to create PR: %v", err)
+	}
+
+	t.Run("Create and Get Traces", func(t *testing.T) {
+		trace := &domain.AssignmentTrace{
+			PullRequestID: "pr-trace-1",
+			Action:        domain.AssignmentActionCreate,
+			Strategy:      domain.StrategyRandom,
+			Seed:          42,
+			Candidates:    []string{"tr-2"},
+			Excluded: []*domain.ExcludedCandidate{
+				{UserID: "tr-1", Reason: domain.ExclusionAuthor},
+				{UserID: "tr-3", Reason: domain.ExclusionInactive},
+			},
+			Selected:  []string{"tr-2"},
+			Loads:     map[string]int{"tr-2": 3},
+			Cursors:   map[string]string{"trace-team": "tr-1"},
+			CreatedAt: time.Now(),
+		}
+		if err := traceRepo.Create(ctx, trace); err != nil {
+			t.Fatalf("Failed to create trace: %v", err)
+		}
+		if trace.ID == 0 {
+			t.Error("Expected trace ID to be set")
+		}
+
+		traces, err := traceRepo.GetByPullRequestID(ctx, "pr-trace-1")
+		if err != nil {
+			t.Fatalf("Failed to get traces: %v", err)
+		}
+		if len(traces) != 1 {
+			t.Fatalf("Expected 1 trace, got %d", len(traces))
+		}
+		if traces[0].Seed != 42 {
+			t.Errorf("Expected seed 42, got %d", traces[0].Seed)
+		}
+		if len(traces[0].Excluded) != 2 {
+			t.Errorf("Expected 2 exclusions, got %d", len(traces[0].Excluded))
+		}
+		if len(traces[0].Selected) != 1 || traces[0].Selected[0] != "tr-2" {
+			t.Errorf("Expected selected [tr-2], got %v", traces[0].Selected)
+		}
+		if traces[0].Loads["tr-2"] != 3 {
+			t.Errorf("Expected load 3 for tr-2, got %v", traces[0].Loads)
+		}
+		if traces[0].Cursors["trace-team"] != "tr-1" {
+			t.Errorf("Expected cursor tr-1 for trace-team, got %v", traces[0].Cursors)
+		}
+	})
+
+	t.Run("Apply Reviewer Change With Trace", func(t *testing.T) {
+		underReviewed := true
+		err := prRepo.ApplyReviewerChange(ctx, &domain.ReviewerChange{
+			PullRequestID: "pr-trace-1",
+			Added:         []string{"tr-3"},
+			UnderReviewed: &underReviewed,
+			Trace: &domain.AssignmentTrace{
+				PullRequestID: "pr-trace-1",
+				Action:        domain.AssignmentActionTopUp,
+				Strategy:      domain.StrategyRandom,
+				Selected:      []string{"tr-3"},
+				CreatedAt:     time.Now(),
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to apply reviewer change: %v", err)
+		}
+		pr, err := prRepo.GetByID(ctx, "pr-trace-1")
+		if err != nil {
+			t.Fatalf("Failed to get PR: %v", err)
+		}
+		if len(pr.AssignedReviewers) != 2 || !pr.UnderReviewed {
+			t.Errorf("Expected 2 reviewers and under-reviewed PR, got %v, %v", pr.AssignedReviewers, pr.UnderReviewed)
+		}
+		traces, err := traceRepo.GetByPullRequestID(ctx, "pr-trace-1")
+		if err != nil {
+			t.Fatalf("Failed to get traces: %v", err)
+		}
+		if len(traces) != 2 || traces[1].Action != domain.AssignmentActionTopUp {
+			t.Errorf("Expected a TOP_UP trace, got %d traces", len(traces))
+		}
+	})
+
+	t.Run("Apply Reviewer Change Rolls Back", func(t *testing.T) {
+		err := prRepo.ApplyReviewerChange(ctx, &domain.ReviewerChange{
+			PullRequestID: "pr-trace-1",
+			Removed:       []string{"tr-1"},
+			Added:         []string{"tr-1"},
+			Trace: &domain.AssignmentTrace{
+				PullRequestID: "pr-trace-1",
+				Action:        domain.AssignmentActionReassign,
+				Strategy:      domain.StrategyRandom,
+				CreatedAt:     time.Now(),
+			},
+		})
+		var domainErr *domain.DomainError
+		if !errors.As(err, &domainErr) || domainErr.Code != domain.ErrNotAssigned {
+			t.Fatalf("Expected NOT_ASSIGNED, got %v", err)
+		}
+		traces, err := traceRepo.GetByPullRequestID(ctx, "pr-trace-1")
+		if err != nil {
+			t.Fatalf("Failed to get traces: %v", err)
+		}
+		if len(traces) != 2 {
+			t.Errorf("Expected no new trace after rollback, got %d traces", len(traces))
+		}
+	})
+
+	t.Run("Create With Trace Rolls Back", func(t *testing.T) {
+		pr := &domain.PullRequest{PullRequestID: "pr-trace-bad", PullRequestName: "Bad trace", AuthorID: "tr-1", Status: domain.PRStatusOpen, CreatedAt: time.Now()}
+		trace := &domain.AssignmentTrace{
+			PullRequestID: "pr-trace-bad",
+			Action:        domain.AssignmentAction("UNKNOWN"),
+			Strategy:      domain.StrategyRandom,
+			CreatedAt:     time.Now(),
+		}
+		if err := prRepo.CreateWithTrace(ctx, pr, trace); err == nil {
+			t.Fatal("Expected an invalid trace to fail")
+		}
+		exists, err := prRepo.Exists(ctx, "pr-trace-bad")
+		if err != nil {
+			t.Fatalf("Failed to check PR: %v", err)
+		}
+		if exists {
+			t.Error("Expected the PR to be rolled back with its trace")
+		}
+	})
+}
+
+func TestReassignmentRepository(t *testing.T) {
+	cleanupDB(t)
+	teamRepo := pg.NewTeam(testPool)
+	userRepo := pg.NewUser(testPool)
+	prRepo := pg.NewPullRequest(testPool)
+	reassignmentRepo := pg.NewReassignment(testPool)
+	err := teamRepo.Create(ctx, &domain.Team{TeamName: "rebalance-team"})
+	if err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+	users := []*domain.User{
+		{UserID: "rb-1", Username: "author", TeamName: "rebalance-team", IsActive: true},
+		{UserID: "rb-2", Username: "busy", TeamName: "rebalance-team", IsActive: true},
+		{UserID: "rb-3", Username: "idle", TeamName: "rebalance-team", IsActive: true},
+	}
+	for _, user := range users {
+		if err := userRepo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user %s: %v", user.UserID, err)
+		}
+	}
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-rebalance-1",
+		PullRequestName:   "Rebalance me",
+		AuthorID:          "rb-1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"rb-2"},
+		CreatedAt:         time.Now(),
+	}
+	if err := prRepo.Create(ctx, pr); err != nil {
+		t.Fatalf("Failed to create PR: %v", err)
+	}
+
+	t.Run("Create and List Recent", func(t *testing.T) {
+		for _, toUserID := range []string{"rb-3", "rb-2"} {
+			reassignment := &domain.Reassignment{
+				PullRequestID: "pr-rebalance-1",
+				TeamName:      "rebalance-team",
+				FromUserID:    "rb-2",
+				ToUserID:      toUserID,
+				Reason:        domain.ReassignmentRebalance,
+				CreatedAt:     time.Now(),
+			}
+			if err := reassignmentRepo.Create(ctx, reassignment); err != nil {
+				t.Fatalf("Failed to create reassignment: %v", err)
+			}
+			if reassignment.ID == 0 {
+				t.Error("Expected reassignment ID to be set")
+			}
+		}
+
+		reassignments, err := reassignmentRepo.ListRecent(ctx, 1)
+		if err != nil {
+			t.Fatalf("Failed to list reassignments: %v", err)
+		}
+		if len(reassignments) != 1 {
+			t.Fatalf("Expected 1 reassignment, got %d", len(reassignments))
+		}
+		if reassignments[0].ToUserID != "rb-2" {
+			t.Errorf("Expected newest reassignment to rb-2, got %s", reassignments[0].ToUserID)
+		}
+	})
+
+	t.Run("List All Team Names", func(t *testing.T) {
+		names, err := teamRepo.GetAllNames(ctx)
+		if err != nil {
+			t.Fatalf("Failed to get team names: %v", err)
+		}
+		if len(names) != 1 || names[0] != "rebalance-team" {
+			t.Errorf("Expected [rebalance-team], got %v", names)
+		}
+	})
+}
+
+func TestAbsenceRepository(t *testing.T) {
+	cleanupDB(t)
+	teamRepo := pg.NewTeam(testPool)
+	userRepo := pg.NewUser(testPool)
+	absenceRepo := pg.NewAbsence(testPool)
+	err := teamRepo.Create(ctx, &domain.Team{TeamName: "absence-team"})
+	if err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+	users := []*domain.User{
+		{UserID: "ab-1", Username: "away", TeamName: "absence-team", IsActive: true},
+		{UserID: "ab-2", Username: "present", TeamName: "absence-team", IsActive: true},
+	}
+	for _, user := range users {
+		if err := userRepo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user %s: %v", user.UserID, err)
+		}
+	}
+
+	now := time.Now()
+	current := &domain.Absence{
+		UserID:   "ab-1",
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(24 * time.Hour),
+		Reason:   "vacation",
+	}
+	upcoming := &domain.Absence{
+		UserID:   "ab-2",
+		StartsAt: now.Add(48 * time.Hour),
+		EndsAt:   now.Add(72 * time.Hour),
+	}
+
+	t.Run("Create and Get By User", func(t *testing.T) {
+		for _, absence := range []*domain.Absence{current, upcoming} {
+			if err := absenceRepo.Create(ctx, absence); err != nil {
+				t.Fatalf("Failed to create absence: %v", err)
+			}
+			if absence.ID == 0 {
+				t.Error("Expected absence ID to be set")
+			}
+		}
+
+		absences, err := absenceRepo.GetByUserID(ctx, "ab-1")
+		if err != nil {
+			t.Fatalf("Failed to get absences: %v", err)
+		}
+		if len(absences) != 1 || absences[0].Reason != "vacation" {
+			t.Errorf("Expected one vacation absence, got %v", absences)
+		}
+	})
+
+	t.Run("Absent Users Are Not Candidates", func(t *testing.T) {
+		absent, err := userRepo.GetAbsentIDs(ctx, []string{"ab-1", "ab-2"}, now)
+		if err != nil {
+			t.Fatalf("Failed to get absent users: %v", err)
+		}
+		if !absent["ab-1"] || absent["ab-2"] {
+			t.Errorf("Expected only ab-1 to be absent, got %v", absent)
+		}
+
+		candidates, err := userRepo.GetActiveByTeamExcluding(ctx, "absence-team", nil)
+		if err != nil {
+			t.Fatalf("Failed to get active users: %v", err)
+		}
+		if len(candidates) != 1 || candidates[0].UserID != "ab-2" {
+			t.Errorf("Expected only ab-2 as candidate, got %v", candidates)
+		}
+	})
+
+	t.Run("Started Unhandled And Mark Handled", func(t *testing.T) {
+		started, err := absenceRepo.GetStartedUnhandled(ctx, now)
+		if err != nil {
+			t.Fatalf("Failed to get started absences: %v", err)
+		}
+		if len(started) != 1 || started[0].ID != current.ID {
+			t.Fatalf("Expected only the current absence, got %v", started)
+		}
+
+		if err := absenceRepo.MarkHandled(ctx, current.ID, now); err != nil {
+			t.Fatalf("Failed to mark absence handled: %v", err)
+		}
+		started, err = absenceRepo.GetStartedUnhandled(ctx, now)
+		if err != nil {
+			t.Fatalf("Failed to get started absences: %v", err)
+		}
+		if len(started) != 0 {
+			t.Errorf("Expected no unhandled absences, got %d", len(started))
+		}
+	})
+
+	t.Run("Delete Absence", func(t *testing.T) {
+		if err := absenceRepo.Delete(ctx, upcoming.ID); err != nil {
+			t.Fatalf("Failed to delete absence: %v", err)
+		}
+		if err := absenceRepo.Delete(ctx, upcoming.ID); err == nil {
+			t.Error("Expected error when deleting missing absence")
+		}
+	})
+}
+
+func TestReviewDeclineRepository(t *testing.T) {
+	cleanupDB(t)
+	teamRepo := pg.NewTeam(testPool)
+	userRepo := pg.NewUser(testPool)
+	prRepo := pg.NewPullRequest(testPool)
+	declineRepo := pg.NewReviewDecline(testPool)
+	err := teamRepo.Create(ctx, &domain.Team{TeamName: "decline-team"})
+	if err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+	users := []*domain.User{
+		{UserID: "dc-1", Username: "author", TeamName: "decline-team", IsActive: true},
+		{UserID: "dc-2", Username: "decliner", TeamName: "decline-team", IsActive: true},
+		{UserID: "dc-3", Username: "stand-in", TeamName: "decline-team", IsActive: true},
+	}
+	for _, user := range users {
+		if err := userRepo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user %s: %v", user.UserID, err)
+		}
+	}
+	for _, prID := range []string{"pr-decline-1", "pr-decline-2"} {
+		pr := &domain.PullRequest{
+			PullRequestID:     prID,
+			PullRequestName:   "Decline me",
+			AuthorID:          "dc-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"dc-2"},
+			CreatedAt:         time.Now(),
+		}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create PR: %v", err)
+		}
+	}
+
+	t.Run("Create and Count By User", func(t *testing.T) {
+		declines := []*domain.ReviewDecline{
+			{PullRequestID: "pr-decline-1", UserID: "dc-2", Reason: domain.DeclineTooBusy, ReplacedBy: "dc-3", CreatedAt: time.Now()},
+			{PullRequestID: "pr-decline-2", UserID: "dc-2", Reason: domain.DeclineTooBusy, CreatedAt: time.Now()},
+			{PullRequestID: "pr-decline-2", UserID: "dc-3", Reason: domain.DeclineConflict, Comment: "pairing with the author", CreatedAt: time.Now()},
+		}
+		for _, decline := range declines {
+			if err := declineRepo.Create(ctx, decline); err != nil {
+				t.Fatalf("Failed to create decline: %v", err)
+			}
+			if decline.ID == 0 {
+				t.Error("Expected decline ID to be set")
+			}
+		}
+
+		counts, err := declineRepo.GetCountsByUsers(ctx, []string{"dc-1", "dc-2", "dc-3"})
+		if err != nil {
+			t.Fatalf("Failed to get decline counts: %v", err)
+		}
+		if counts["dc-2"][domain.DeclineTooBusy] != 2 {
+			t.Errorf("Expected 2 TOO_BUSY declines for dc-2, got %d", counts["dc-2"][domain.DeclineTooBusy])
+		}
+		if counts["dc-3"][domain.DeclineConflict] != 1 {
+			t.Errorf("Expected 1 CONFLICT decline for dc-3, got %d", counts["dc-3"][domain.DeclineConflict])
+		}
+		if len(counts["dc-1"]) != 0 {
+			t.Errorf("Expected no declines for dc-1, got %v", counts["dc-1"])
+		}
+	})
+}
+
+func TestReviewRepository(t *testing.T) {
+	cleanupDB(t)
+	teamRepo := pg.NewTeam(testPool)
+	userRepo := pg.NewUser(testPool)
+	prRepo := pg.NewPullRequest(testPool)
+	reviewRepo := pg.NewReview(testPool)
+	err := teamRepo.Create(ctx, &domain.Team{TeamName: "verdict-team"})
+	if err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+	users := []*domain.User{
+		{UserID: "vd-1", Username: "author", TeamName: "verdict-team", IsActive: true},
+		{UserID: "vd-2", Username: "reviewer", TeamName: "verdict-team", IsActive: true},
+	}
+	for _, user := range users {
+		if err := userRepo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user %s: %v", user.UserID, err)
+		}
+	}
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-verdict-1",
+		PullRequestName:   "Review me",
+		AuthorID:          "vd-1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"vd-2"},
+		CreatedAt:         time.Now(),
+	}
+	if err := prRepo.Create(ctx, pr); err != nil {
+		t.Fatalf("Failed to create PR: %v", err)
+	}
+
+	t.Run("History Is Kept", func(t *testing.T) {
+		first := time.Now().Add(-time.Hour)
+		reviews := []*domain.Review{
+			{PullRequestID: "pr-verdict-1", UserID: "vd-2", Verdict: domain.VerdictChangesRequested, Body: "missing tests", CreatedAt: first},
+			{PullRequestID: "pr-verdict-1", UserID: "vd-2", Verdict: domain.VerdictApproved, CreatedAt: first.Add(30 * time.Minute)},
+		}
+		for _, review := range reviews {
+			if err := reviewRepo.Create(ctx, review); err != nil {
+				t.Fatalf("Failed to create review: %v", err)
+			}
+			if review.ID == 0 {
+				t.Error("Expected review ID to be set")
+			}
+		}
+
+		history, err := reviewRepo.GetByPullRequestID(ctx, "pr-verdict-1")
+		if err != nil {
+			t.Fatalf("Failed to get reviews: %v", err)
+		}
+		if len(history) != 2 {
+			t.Fatalf("Expected 2 reviews, got %d", len(history))
+		}
+		if history[0].Verdict != domain.VerdictChangesRequested || history[0].Body != "missing tests" {
+			t.Errorf("Expected first review to be CHANGES_REQUESTED with body, got %s %q", history[0].Verdict, history[0].Body)
+		}
+		if history[1].Verdict != domain.VerdictApproved {
+			t.Errorf("Expected latest review to be APPROVED, got %s", history[1].Verdict)
+		}
+	})
+
+	t.Run("Unknown PR Has No Reviews", func(t *testing.T) {
+		history, err := reviewRepo.GetByPullRequestID(ctx, "pr-verdict-missing")
+		if err != nil {
+			t.Fatalf("Failed to get reviews: %v", err)
+		}
+		if len(history) != 0 {
+			t.Errorf("Expected no reviews, got %d", len(history))
+		}
+	})
+}
+
+func TestTeamPolicyRepository(t *testing.T) {
+	cleanupDB(t)
+	teamRepo := pg.NewTeam(testPool)
+	policyRepo := pg.NewTeamPolicy(testPool)
+	err := teamRepo.Create(ctx, &domain.Team{TeamName: "policy-team"})
+	if err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+
+	t.Run("Get Missing Policy", func(t *testing.T) {
+		_, err := policyRepo.GetByTeamName(ctx, "policy-team")
+		if err == nil {
+			t.Error("Expected error when policy is not set")
+		}
+	})
+
+	t.Run("Upsert Policy", func(t *testing.T) {
+		policy := &domain.TeamPolicy{TeamName: "policy-team", MinReviewers: 1, MaxReviewers: 3, AllowCrossTeam: true, WorkingHoursMode: domain.WorkingHoursSoft}
+		if err := policyRepo.Upsert(ctx, policy); err != nil {
+			t.Fatalf("Failed to save policy: %v", err)
+		}
+		policy.AllowCrossTeam = false
+		policy.MinReviewers = 2
+		policy.RequireSenior = true
+		policy.WorkingHoursMode = domain.WorkingHoursStrict
+		policy.StartWithinHours = 2
+		policy.MaxOpenReviews = 4
+		policy.RequiredApprovals = 2
+		if err := policyRepo.Upsert(ctx, policy); err != nil {
+			t.Fatalf("Failed to update policy: %v", err)
+		}
+
+		retrieved, err := policyRepo.GetByTeamName(ctx, "policy-team")
+		if err != nil {
+			t.Fatalf("Failed to get policy: %v", err)
+		}
+		if retrieved.MinReviewers != 2 || retrieved.MaxReviewers != 3 {
+			t.Errorf("Expected min 2 and max 3, got %d and %d", retrieved.MinReviewers, retrieved.MaxReviewers)
+		}
+		if retrieved.AllowCrossTeam {
+			t.Error("Expected cross-team reviewers to be disallowed")
+		}
+		if !retrieved.RequireSenior {
+			t.Error("Expected senior reviewer to be required")
+		}
+		if retrieved.MaxOpenReviews != 4 {
+			t.Errorf("Expected max open reviews 4, got %d", retrieved.MaxOpenReviews)
+		}
+		if retrieved.RequiredApprovals != 2 {
+			t.Errorf("Expected 2 required approvals, got %d", retrieved.RequiredApprovals)
+		}
+		if retrieved.WorkingHoursMode != domain.WorkingHoursStrict || retrieved.StartWithinHours != 2 {
+			t.Errorf("Expected STRICT working hours starting within 2h, got %s and %d", retrieved.WorkingHoursMode, retrieved.StartWithinHours)
+		}
+	})
+
+	t.Run("Required Approvals Above Min Reviewers", func(t *testing.T) {
+		policy := &domain.TeamPolicy{TeamName: "policy-team", MinReviewers: 0, MaxReviewers: 3, RequiredApprovals: 1, WorkingHoursMode: domain.WorkingHoursSoft}
+		if err := policyRepo.Upsert(ctx, policy); err == nil {
+			t.Error("Expected error when required approvals exceed min reviewers")
+		}
+	})
+}
+
+func TestOwnershipRepository(t *testing.T) {
+	cleanupDB(t)
+	ownershipRepo := pg.NewOwnership(testPool)
+
+	t.Run("Replace and Get Rules", func(t *testing.T) {
+		rules := []*domain.OwnershipRule{
+			{Pattern: "*", Owners: []*domain.CodeOwner{{Type: domain.OwnerTypeTeam, ID: "backend"}}},
+			{Pattern: "/docs/", Owners: []*domain.CodeOwner{{Type: domain.OwnerTypeUser, ID: "u3"}}},
+		}
+		if err := ownershipRepo.ReplaceAll(ctx, rules); err != nil {
+			t.Fatalf("Failed to replace rules: %v", err)
+		}
+
+		retrieved, err := ownershipRepo.GetAll(ctx)
+		if err != nil {
+			t.Fatalf("Failed to get rules: %v", err)
+		}
+		if len(retrieved) != 2 {
+			t.Fatalf("Expected 2 rules, got %d", len(retrieved))
+		}
+		if retrieved[1].Pattern != "/docs/" {
+			t.Errorf("Expected second rule /docs/, got %s", retrieved[1].Pattern)
+		}
+		if len(retrieved[1].Owners) != 1 || retrieved[1].Owners[0].ID != "u3" {
+			t.Errorf("Expected owner u3, got %v", retrieved[1].Owners)
+		}
+	})
+
+	t.Run("Replace With Empty Rules", func(t *testing.T) {
+		if err := ownershipRepo.ReplaceAll(ctx, []*domain.OwnershipRule{}); err != nil {
+			t.Fatalf("Failed to replace rules: %v", err)
+		}
+		retrieved, err := ownershipRepo.GetAll(ctx)
+		if err != nil {
+			t.Fatalf("Failed to get rules: %v", err)
+		}
+		if len(retrieved) != 0 {
+			t.Errorf("Expected 0 rules, got %d", len(retrieved))
+		}
+	})
+}
+
+func TestReviewerRuleRepository(t *testing.T) {
+	cleanupDB(t)
+	teamRepo := pg.NewTeam(testPool)
+	userRepo := pg.NewUser(testPool)
+	prRepo := pg.NewPullRequest(testPool)
+	ruleRepo := pg.NewReviewerRule(testPool)
+	err := teamRepo.Create(ctx, &domain.Team{TeamName: "rule-team"})
+	if err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+	users := []*domain.User{
+		{UserID: "rl-1", Username: "author", TeamName: "rule-team", IsActive: true},
+		{UserID: "rl-2", Username: "avoided", TeamName: "rule-team", IsActive: true},
+		{UserID: "rl-3", Username: "other", TeamName: "rule-team", IsActive: true},
+	}
+	for _, user := range users {
+		if err := userRepo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user %s: %v", user.UserID, err)
+		}
+	}
+
+	t.Run("Create and Get For Author", func(t *testing.T) {
+		avoid := &domain.ReviewerRule{Type: domain.RuleAvoid, AuthorID: "rl-1", ReviewerID: "rl-2", CreatedAt: time.Now()}
+		if err := ruleRepo.Create(ctx, avoid); err != nil {
+			t.Fatalf("Failed to create rule: %v", err)
+		}
+		if avoid.ID == 0 {
+			t.Error("Expected rule ID to be set")
+		}
+		noRepeat := &domain.ReviewerRule{Type: domain.RuleNoRepeat, CreatedAt: time.Now()}
+		if err := ruleRepo.Create(ctx, noRepeat); err != nil {
+			t.Fatalf("Failed to create rule: %v", err)
+		}
+		other := &domain.ReviewerRule{Type: domain.RulePrefer, AuthorID: "rl-3", ReviewerID: "rl-2", CreatedAt: time.Now()}
+		if err := ruleRepo.Create(ctx, other); err != nil {
+			t.Fatalf("Failed to create rule: %v", err)
+		}
+
+		rules, err := ruleRepo.GetForAuthor(ctx, "rl-1")
+		if err != nil {
+			t.Fatalf("Failed to get rules: %v", err)
+		}
+		if len(rules) != 2 {
+			t.Fatalf("Expected 2 rules for author, got %d", len(rules))
+		}
+		all, err := ruleRepo.List(ctx)
+		if err != nil {
+			t.Fatalf("Failed to list rules: %v", err)
+		}
+		if len(all) != 3 {
+			t.Errorf("Expected 3 rules, got %d", len(all))
+		}
+	})
+
+	t.Run("Delete Rule", func(t *testing.T) {
+		all, err := ruleRepo.List(ctx)
+		if err != nil {
+			t.Fatalf("Failed to list rules: %v", err)
+		}
+		if err := ruleRepo.Delete(ctx, all[0].ID); err != nil {
+			t.Fatalf("Failed to delete rule: %v", err)
+		}
+		if err := ruleRepo.Delete(ctx, all[0].ID); err == nil {
+			t.Error("Expected error when deleting missing rule")
+		}
+	})
+
+	t.Run("Get Last Reviewers By Author", func(t *testing.T) {
+		prs := []*domain.PullRequest{
+			{PullRequestID: "pr-rule-1", PullRequestName: "First", AuthorID: "rl-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"rl-2"}, CreatedAt: time.Now().Add(-time.Hour)},
+			{PullRequestID: "pr-rule-2", PullRequestName: "Second", AuthorID: "rl-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"rl-3"}, CreatedAt: time.Now()},
+		}
+		for _, pr := range prs {
+			if err := prRepo.Create(ctx, pr); err != nil {
+				t.Fatalf("Failed to create PR: %v", err)
+			}
+		}
+
+		reviewers, err := prRepo.GetLastReviewersByAuthor(ctx, "rl-1", "pr-rule-3")
+		if err != nil {
+			t.Fatalf("Failed to get last reviewers: %v", err)
+		}
+		if len(reviewers) != 1 || reviewers[0] != "rl-3" {
+			t.Errorf("Expected last reviewers [rl-3], got %v", reviewers)
+		}
+		reviewers, err = prRepo.GetLastReviewersByAuthor(ctx, "rl-1", "pr-rule-2")
+		if err != nil {
+			t.Fatalf("Failed to get last reviewers: %v", err)
+		}
+		if len(reviewers) != 1 || reviewers[0] != "rl-2" {
+			t.Errorf("Expected last reviewers [rl-2], got %v", reviewers)
+		}
+	})
+}
+
+func TestComplexScenario(t *testing.T) {
+	cleanupDB(t)
+	teamRepo := pg.NewTeam(testPool)
+	userRepo := pg.NewUser(testPool)
+	prRepo := pg.NewPullRequest(testPool)
+	t.Run("Full Workflow", func(t *testing.T) {
+		team := &domain.Team{TeamName: "fullstack-team"}
+		err := teamRepo.Create(ctx, team)
+		if err != nil {
+			t.Fatalf("Failed to create team: %v", err)
+		}
+		users := []*domain.User{
+			{UserID: "fs-1", Username: "john", TeamName: "fullstack-team", IsActive: true},
+			{UserID: "fs-2", Username: "jane", TeamName: "fullstack-team", IsActive: true},
+			{UserID: "fs-3", Username: "jack", TeamName: "fullstack-team", IsActive: false},
+		}
+
+		for _, user := range users {
+			err := userRepo.Create(ctx, user)
+			if err != nil {
+				t.Fatalf("Failed to create user %s: %v", user.UserID, err)
+			}
+		}
+		pr := &domain.PullRequest{
+			PullRequestID:     "pr-complex-1",
+			PullRequestName:   "Refactor database layer",
+			AuthorID:          "fs-1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"fs-2"},
+			CreatedAt:         time.Now(),
+		}
+
+		err = prRepo.Create(ctx, pr)
+		if err != nil {
+			t.Fatalf("Failed to create PR: %v", err)
+		}
+		teamExists, err := teamRepo.Exists(ctx, "fullstack-team")
+		if err != nil || !teamExists {
+			t.Error("Team should exist")
+		}
+
+		teamUsers, err := userRepo.GetByTeamName(ctx, "fullstack-team")
+		if err != nil {
+			t.Fatalf("Failed to get team users: %v", err)
+		}
+		if len(teamUsers) != 3 {
+			t.Errorf("Expected 3 users in team, got %d", len(teamUsers))
+		}
+
+		retrievedPR, err := prRepo.GetByID(ctx, "pr-complex-1")
+		if err != nil {
+			t.Fatalf("Failed to get PR: %v", err)
+		}
+		if retrievedPR.Status != domain.PRStatusOpen {
+			t.Errorf("Expected PR status OPEN, got %s", retrievedPR.Status)
+		}
+		_, err = userRepo.SetIsActive(ctx, "fs-2", false)
+		if err != nil {
+			t.Fatalf("Failed to deactivate user: %v", err)
+		}
+		activeUsers, err := userRepo.GetActiveByTeamExcluding(ctx, "fullstack-team", []string{})
+		if err != nil {
+			t.Fatalf("Failed to get active users: %v", err)
+		}
+		if len(activeUsers) != 1 {
+			t.Errorf("Expected 1 active user, got %d", len(activeUsers))
+		}
+		err = prRepo.SetMerged(ctx, "pr-complex-1")
+		if err != nil {
+			t.Fatalf("Failed to merge PR: %v", err)
+		}
+
+		finalPR, err := prRepo.GetByID(ctx, "pr-complex-1")
+		if err != nil {
+			t.Fatalf("Failed to get final PR: %v", err)
+		}
+		if finalPR.Status != domain.PRStatusMerged {
 			t.Errorf("Expected PR status MERGED, got %s", finalPR.Status)
 		}
 	})
 }
+
+func newPullRequestCase(seed int64) *usecase.PullRequest {
+	return newAdminPullRequestCase(seed, nil)
+}
+
+func newAdminPullRequestCase(seed int64, adminTokens map[string]string) *usecase.PullRequest {
+	defaultPolicy := domain.TeamPolicy{
+		MinReviewers:     1,
+		MaxReviewers:     2,
+		AllowCrossTeam:   true,
+		WorkingHoursMode: domain.WorkingHoursSoft,
+	}
+	return usecase.NewPullRequest(
+		pg.NewPullRequest(testPool), pg.NewUser(testPool), pg.NewTeam(testPool), pg.NewAssignmentTrace(testPool),
+		pg.NewOwnership(testPool), pg.NewTeamPolicy(testPool), pg.NewReviewerRule(testPool),
+		pg.NewReview(testPool), func() int64 { return seed }, defaultPolicy, adminTokens,
+	)
+}
+
+func createTeamWithUsers(t *testing.T, teamName string, users ...*domain.User) {
+	t.Helper()
+	if err := pg.NewTeam(testPool).Create(ctx, &domain.Team{TeamName: teamName}); err != nil {
+		t.Fatalf("Failed to create team %s: %v", teamName, err)
+	}
+	userRepo := pg.NewUser(testPool)
+	for _, user := range users {
+		user.TeamName = teamName
+		if err := userRepo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user %s: %v", user.UserID, err)
+		}
+	}
+}
+
+func TestCodeOwnersMatching(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "owners-team",
+		&domain.User{UserID: "ow-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "ow-2", Username: "docs-owner", IsActive: true},
+		&domain.User{UserID: "ow-3", Username: "api-owner", IsActive: true},
+		&domain.User{UserID: "ow-4", Username: "backend-owner", IsActive: true},
+	)
+	rules := []*domain.OwnershipRule{
+		{Pattern: "docs/*", Owners: []*domain.CodeOwner{{Type: domain.OwnerTypeUser, ID: "ow-2"}}},
+		{Pattern: "api/", Owners: []*domain.CodeOwner{{Type: domain.OwnerTypeUser, ID: "ow-3"}}},
+		{Pattern: "/src/backend", Owners: []*domain.CodeOwner{{Type: domain.OwnerTypeUser, ID: "ow-4"}}},
+	}
+	if err := pg.NewOwnership(testPool).ReplaceAll(ctx, rules); err != nil {
+		t.Fatalf("Failed to replace rules: %v", err)
+	}
+	cases := newPullRequestCase(1)
+
+	ownersOf := func(t *testing.T, path string) []string {
+		t.Helper()
+		preview, err := cases.PreviewAssignment(ctx, &usecase.CreatePullRequestParams{
+			AuthorID:     "ow-1",
+			ChangedFiles: []string{path},
+		})
+		if err != nil {
+			t.Fatalf("Failed to preview assignment: %v", err)
+		}
+		owners := []string{}
+		for _, candidate := range preview.Candidates {
+			if candidate.Source == domain.ReviewerSourceCodeOwners {
+				owners = append(owners, candidate.UserID)
+			}
+		}
+		return owners
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "docs/readme.md", want: "ow-2"},
+		{path: "docs/guides/setup.md", want: ""},
+		{path: "api/handler.go", want: "ow-3"},
+		{path: "api/v1/handler.go", want: "ow-3"},
+		{path: "src/backend/main.go", want: "ow-4"},
+		{path: "src/backend/store/pg.go", want: "ow-4"},
+		{path: "src/backendx/main.go", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			owners := ownersOf(t, tt.path)
+			if tt.want == "" && len(owners) != 0 {
+				t.Errorf("Expected no code owners, got %v", owners)
+			}
+			if tt.want != "" && (len(owners) != 1 || owners[0] != tt.want) {
+				t.Errorf("Expected code owners [%s], got %v", tt.want, owners)
+			}
+		})
+	}
+}
+
+func TestLevelPreferenceRule(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "level-team",
+		&domain.User{UserID: "lv-1", Username: "junior", Level: domain.LevelJunior, IsActive: true},
+		&domain.User{UserID: "lv-2", Username: "middle-a", Level: domain.LevelMiddle, IsActive: true},
+		&domain.User{UserID: "lv-3", Username: "middle-b", Level: domain.LevelMiddle, IsActive: true},
+		&domain.User{UserID: "lv-4", Username: "middle-c", Level: domain.LevelMiddle, IsActive: true},
+		&domain.User{UserID: "lv-5", Username: "senior", Level: domain.LevelSenior, IsActive: true},
+	)
+	rules := usecase.NewReviewerRules(pg.NewReviewerRule(testPool), pg.NewUser(testPool))
+
+	t.Run("Reject Invalid Level Rules", func(t *testing.T) {
+		invalid := []*domain.ReviewerRule{
+			{Type: domain.RuleAvoid, AuthorLevel: domain.LevelJunior, ReviewerLevel: domain.LevelSenior},
+			{Type: domain.RulePrefer, AuthorLevel: domain.LevelJunior},
+			{Type: domain.RulePrefer, ReviewerLevel: "GURU"},
+			{Type: domain.RulePrefer, ReviewerID: "lv-5", ReviewerLevel: domain.LevelSenior},
+		}
+		for _, rule := range invalid {
+			if _, err := rules.CreateRule(ctx, rule); err == nil {
+				t.Errorf("Expected error for rule %+v", rule)
+			}
+		}
+	})
+
+	t.Run("Prefer Senior For Junior Authors", func(t *testing.T) {
+		rule, err := rules.CreateRule(ctx, &domain.ReviewerRule{
+			Type:          domain.RulePrefer,
+			AuthorLevel:   domain.LevelJunior,
+			ReviewerLevel: domain.LevelSenior,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create rule: %v", err)
+		}
+		if rule.ReviewerLevel != domain.LevelSenior {
+			t.Errorf("Expected reviewer level SENIOR, got %s", rule.ReviewerLevel)
+		}
+
+		for seed := int64(1); seed <= 5; seed++ {
+			preview, err := newPullRequestCase(seed).PreviewAssignment(ctx, &usecase.CreatePullRequestParams{AuthorID: "lv-1"})
+			if err != nil {
+				t.Fatalf("Failed to preview assignment: %v", err)
+			}
+			found := false
+			for _, reviewerID := range preview.ProposedReviewers {
+				found = found || reviewerID == "lv-5"
+			}
+			if !found {
+				t.Errorf("Seed %d: expected senior lv-5 among %v", seed, preview.ProposedReviewers)
+			}
+		}
+	})
+}
+
+func expectDomainError(t *testing.T, err error, code domain.ErrorCode) {
+	t.Helper()
+	var domainErr *domain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != code {
+		t.Errorf("Expected %s error, got %v", code, err)
+	}
+}
+
+func TestReassignChosenReviewer(t *testing.T) {
+	cleanupDB(t)
+	oneReview := 1
+	createTeamWithUsers(t, "chosen-team",
+		&domain.User{UserID: "ch-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "ch-2", Username: "reviewer", IsActive: true},
+		&domain.User{UserID: "ch-3", Username: "replacement", IsActive: true},
+		&domain.User{UserID: "ch-4", Username: "inactive", IsActive: false},
+		&domain.User{UserID: "ch-5", Username: "absent", IsActive: true},
+		&domain.User{UserID: "ch-6", Username: "busy", IsActive: true, MaxOpenReviews: &oneReview},
+		&domain.User{UserID: "ch-7", Username: "avoided", IsActive: true},
+	)
+	createTeamWithUsers(t, "chosen-other",
+		&domain.User{UserID: "ch-8", Username: "outsider", IsActive: true},
+	)
+	prRepo := pg.NewPullRequest(testPool)
+	prs := []*domain.PullRequest{
+		{PullRequestID: "pr-chosen-1", PullRequestName: "Chosen", AuthorID: "ch-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"ch-2"}, CreatedAt: time.Now()},
+		{PullRequestID: "pr-chosen-busy", PullRequestName: "Busy", AuthorID: "ch-8", Status: domain.PRStatusOpen, AssignedReviewers: []string{"ch-6"}, CreatedAt: time.Now()},
+	}
+	for _, pr := range prs {
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create PR %s: %v", pr.PullRequestID, err)
+		}
+	}
+	absence := &domain.Absence{UserID: "ch-5", StartsAt: time.Now().Add(-time.Hour), EndsAt: time.Now().Add(time.Hour), CreatedAt: time.Now()}
+	if err := pg.NewAbsence(testPool).Create(ctx, absence); err != nil {
+		t.Fatalf("Failed to create absence: %v", err)
+	}
+	avoid := &domain.ReviewerRule{Type: domain.RuleAvoid, AuthorID: "ch-1", ReviewerID: "ch-7", CreatedAt: time.Now()}
+	if err := pg.NewReviewerRule(testPool).Create(ctx, avoid); err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	cases := newPullRequestCase(1)
+
+	t.Run("Reject Ineligible Reviewers", func(t *testing.T) {
+		rejected := []struct {
+			reviewerID string
+			code       domain.ErrorCode
+		}{
+			{reviewerID: "ch-1", code: domain.ErrNoCandidate},
+			{reviewerID: "ch-2", code: domain.ErrNoCandidate},
+			{reviewerID: "ch-4", code: domain.ErrNoCandidate},
+			{reviewerID: "ch-5", code: domain.ErrNoCandidate},
+			{reviewerID: "ch-6", code: domain.ErrCapacityExhausted},
+			{reviewerID: "ch-7", code: domain.ErrNoCandidate},
+		}
+		for _, tt := range rejected {
+			_, _, err := cases.ReassignReviewer(ctx, "pr-chosen-1", "ch-2", tt.reviewerID)
+			expectDomainError(t, err, tt.code)
+		}
+		reviewers, err := prRepo.GetReviewers(ctx, "pr-chosen-1")
+		if err != nil {
+			t.Fatalf("Failed to get reviewers: %v", err)
+		}
+		if len(reviewers) != 1 || reviewers[0] != "ch-2" {
+			t.Errorf("Expected reviewers unchanged [ch-2], got %v", reviewers)
+		}
+	})
+
+	t.Run("Reassign To Chosen Reviewer", func(t *testing.T) {
+		pr, replacedBy, err := cases.ReassignReviewer(ctx, "pr-chosen-1", "ch-2", "ch-3")
+		if err != nil {
+			t.Fatalf("Failed to reassign reviewer: %v", err)
+		}
+		if replacedBy != "ch-3" {
+			t.Errorf("Expected replacement ch-3, got %s", replacedBy)
+		}
+		if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "ch-3" {
+			t.Errorf("Expected reviewers [ch-3], got %v", pr.AssignedReviewers)
+		}
+		traces, err := cases.GetAssignmentTraces(ctx, "pr-chosen-1")
+		if err != nil {
+			t.Fatalf("Failed to get traces: %v", err)
+		}
+		if len(traces) != 1 || traces[0].Action != domain.AssignmentActionReassignChosen {
+			t.Fatalf("Expected one REASSIGN_CHOSEN trace, got %d traces", len(traces))
+		}
+		if len(traces[0].Selected) != 1 || traces[0].Selected[0] != "ch-3" {
+			t.Errorf("Expected selected [ch-3], got %v", traces[0].Selected)
+		}
+	})
+
+	t.Run("Reject Cross Team Reviewer", func(t *testing.T) {
+		policy := &domain.TeamPolicy{TeamName: "chosen-team", MinReviewers: 1, MaxReviewers: 2, WorkingHoursMode: domain.WorkingHoursSoft}
+		if err := pg.NewTeamPolicy(testPool).Upsert(ctx, policy); err != nil {
+			t.Fatalf("Failed to upsert policy: %v", err)
+		}
+		_, _, err := cases.ReassignReviewer(ctx, "pr-chosen-1", "ch-3", "ch-8")
+		expectDomainError(t, err, domain.ErrNoCandidate)
+	})
+}
+
+func TestManualReviewerChanges(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "manual-team",
+		&domain.User{UserID: "mn-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "mn-2", Username: "first", IsActive: true},
+		&domain.User{UserID: "mn-3", Username: "second", IsActive: true},
+		&domain.User{UserID: "mn-4", Username: "third", IsActive: true},
+		&domain.User{UserID: "mn-5", Username: "fourth", IsActive: true},
+	)
+	policy := &domain.TeamPolicy{TeamName: "manual-team", MinReviewers: 1, MaxReviewers: 3, AllowCrossTeam: true, WorkingHoursMode: domain.WorkingHoursSoft}
+	if err := pg.NewTeamPolicy(testPool).Upsert(ctx, policy); err != nil {
+		t.Fatalf("Failed to upsert policy: %v", err)
+	}
+	prRepo := pg.NewPullRequest(testPool)
+	prs := []*domain.PullRequest{
+		{PullRequestID: "pr-manual-1", PullRequestName: "Manual", AuthorID: "mn-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"mn-2"}, CreatedAt: time.Now()},
+		{PullRequestID: "pr-manual-draft", PullRequestName: "Draft", AuthorID: "mn-1", Status: domain.PRStatusDraft, CreatedAt: time.Now()},
+	}
+	for _, pr := range prs {
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create PR %s: %v", pr.PullRequestID, err)
+		}
+	}
+	cases := newPullRequestCase(1)
+
+	t.Run("Add Chosen Reviewers Up To Maximum", func(t *testing.T) {
+		for _, userID := range []string{"mn-3", "mn-4"} {
+			if _, err := cases.AddReviewer(ctx, "pr-manual-1", userID); err != nil {
+				t.Fatalf("Failed to add reviewer %s: %v", userID, err)
+			}
+		}
+		_, err := cases.AddReviewer(ctx, "pr-manual-1", "mn-5")
+		expectDomainError(t, err, domain.ErrReviewerLimit)
+
+		traces, err := cases.GetAssignmentTraces(ctx, "pr-manual-1")
+		if err != nil {
+			t.Fatalf("Failed to get traces: %v", err)
+		}
+		if len(traces) != 2 || traces[0].Action != domain.AssignmentActionAddChosen {
+			t.Errorf("Expected two ADD_CHOSEN traces, got %d traces", len(traces))
+		}
+	})
+
+	t.Run("Remove Reviewers And Top Up To Minimum", func(t *testing.T) {
+		for _, userID := range []string{"mn-2", "mn-3"} {
+			pr, err := cases.RemoveReviewer(ctx, "pr-manual-1", userID)
+			if err != nil {
+				t.Fatalf("Failed to remove reviewer %s: %v", userID, err)
+			}
+			if pr.UnderReviewed {
+				t.Errorf("Expected PR not to be under-reviewed after removing %s", userID)
+			}
+		}
+		pr, err := cases.RemoveReviewer(ctx, "pr-manual-1", "mn-4")
+		if err != nil {
+			t.Fatalf("Failed to remove reviewer mn-4: %v", err)
+		}
+		if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] == "mn-4" {
+			t.Errorf("Expected one replacement other than mn-4, got %v", pr.AssignedReviewers)
+		}
+		reviewers, err := prRepo.GetReviewers(ctx, "pr-manual-1")
+		if err != nil {
+			t.Fatalf("Failed to get reviewers: %v", err)
+		}
+		if len(reviewers) != 1 {
+			t.Errorf("Expected top-up to the minimum of 1, got %v", reviewers)
+		}
+		traces, err := cases.GetAssignmentTraces(ctx, "pr-manual-1")
+		if err != nil {
+			t.Fatalf("Failed to get traces: %v", err)
+		}
+		last := traces[len(traces)-1]
+		if last.Action != domain.AssignmentActionTopUp {
+			t.Fatalf("Expected last trace TOP_UP, got %s", last.Action)
+		}
+		removed := false
+		for _, excluded := range last.Excluded {
+			removed = removed || (excluded.UserID == "mn-4" && excluded.Reason == domain.ExclusionRemoved)
+		}
+		if !removed {
+			t.Errorf("Expected mn-4 excluded as REMOVED, got %v", last.Excluded)
+		}
+	})
+
+	t.Run("Reject Invalid Removals", func(t *testing.T) {
+		_, err := cases.RemoveReviewer(ctx, "pr-manual-1", "mn-4")
+		expectDomainError(t, err, domain.ErrNotAssigned)
+		_, err = cases.RemoveReviewer(ctx, "pr-manual-draft", "mn-2")
+		expectDomainError(t, err, domain.ErrPRDraft)
+	})
+}
+
+// failingLabelsRepo fails to load labels for one PR, so planning breaks
+// part-way through a batch.
+type failingLabelsRepo struct {
+	*pg.PullRequest
+	prID string
+}
+
+func (r *failingLabelsRepo) GetLabels(ctx context.Context, prID string) ([]string, error) {
+	if prID == r.prID {
+		return nil, errors.New("labels unavailable")
+	}
+	return r.PullRequest.GetLabels(ctx, prID)
+}
+
+func TestHandOffReviews(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "handoff-team",
+		&domain.User{UserID: "ho-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "ho-2", Username: "leaving", IsActive: true},
+		&domain.User{UserID: "ho-3", Username: "first", IsActive: true},
+		&domain.User{UserID: "ho-4", Username: "second", IsActive: true},
+	)
+	policy := &domain.TeamPolicy{TeamName: "handoff-team", MinReviewers: 1, MaxReviewers: 1, AllowCrossTeam: true, WorkingHoursMode: domain.WorkingHoursSoft, MaxOpenReviews: 1}
+	if err := pg.NewTeamPolicy(testPool).Upsert(ctx, policy); err != nil {
+		t.Fatalf("Failed to upsert policy: %v", err)
+	}
+	prRepo := pg.NewPullRequest(testPool)
+	for _, prID := range []string{"pr-handoff-1", "pr-handoff-2"} {
+		pr := &domain.PullRequest{PullRequestID: prID, PullRequestName: prID, AuthorID: "ho-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"ho-2"}, CreatedAt: time.Now()}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create PR %s: %v", prID, err)
+		}
+	}
+
+	t.Run("Failure Leaves Every Review In Place", func(t *testing.T) {
+		cases := usecase.NewPullRequest(
+			&failingLabelsRepo{PullRequest: prRepo, prID: "pr-handoff-2"}, pg.NewUser(testPool), pg.NewTeam(testPool), pg.NewAssignmentTrace(testPool),
+			pg.NewOwnership(testPool), pg.NewTeamPolicy(testPool), pg.NewReviewerRule(testPool),
+			pg.NewReview(testPool), func() int64 { return 1 }, *policy, nil,
+		)
+		if _, err := cases.HandOffReviews(ctx, "ho-2"); err == nil {
+			t.Fatal("Expected handoff to fail")
+		}
+		for _, prID := range []string{"pr-handoff-1", "pr-handoff-2"} {
+			reviewers, err := prRepo.GetReviewers(ctx, prID)
+			if err != nil {
+				t.Fatalf("Failed to get reviewers: %v", err)
+			}
+			if len(reviewers) != 1 || reviewers[0] != "ho-2" {
+				t.Errorf("Expected %s to keep ho-2, got %v", prID, reviewers)
+			}
+			traces, err := pg.NewAssignmentTrace(testPool).GetByPullRequestID(ctx, prID)
+			if err != nil {
+				t.Fatalf("Failed to get traces: %v", err)
+			}
+			if len(traces) != 0 {
+				t.Errorf("Expected no traces on %s, got %d", prID, len(traces))
+			}
+		}
+	})
+
+	t.Run("Success Moves Every Review Within Capacity", func(t *testing.T) {
+		handoffs, err := newPullRequestCase(1).HandOffReviews(ctx, "ho-2")
+		if err != nil {
+			t.Fatalf("Failed to hand off reviews: %v", err)
+		}
+		if len(handoffs) != 2 {
+			t.Fatalf("Expected 2 handoffs, got %d", len(handoffs))
+		}
+		replacements := make(map[string]bool)
+		for _, handoff := range handoffs {
+			if handoff.Outcome != domain.HandoffReassigned {
+				t.Fatalf("Expected %s to be reassigned, got %s", handoff.PullRequestID, handoff.Outcome)
+			}
+			replacements[handoff.ReplacedBy] = true
+			reviewers, err := prRepo.GetReviewers(ctx, handoff.PullRequestID)
+			if err != nil {
+				t.Fatalf("Failed to get reviewers: %v", err)
+			}
+			if len(reviewers) != 1 || reviewers[0] != handoff.ReplacedBy {
+				t.Errorf("Expected %s reviewed by %s, got %v", handoff.PullRequestID, handoff.ReplacedBy, reviewers)
+			}
+			traces, err := pg.NewAssignmentTrace(testPool).GetByPullRequestID(ctx, handoff.PullRequestID)
+			if err != nil {
+				t.Fatalf("Failed to get traces: %v", err)
+			}
+			if len(traces) != 1 || traces[0].Action != domain.AssignmentActionReassign {
+				t.Errorf("Expected one REASSIGN trace on %s, got %d traces", handoff.PullRequestID, len(traces))
+			}
+		}
+		if !replacements["ho-3"] || !replacements["ho-4"] {
+			t.Errorf("Expected reviews split between ho-3 and ho-4 under max_open_reviews 1, got %v", replacements)
+		}
+	})
+}
+
+// failingRedistributionRepo fails the bulk write of a redistribution.
+type failingRedistributionRepo struct {
+	*pg.PullRequest
+}
+
+func (r *failingRedistributionRepo) ApplyRedistribution(ctx context.Context, redistribution *domain.ReviewRedistribution) error {
+	return errors.New("redistribution unavailable")
+}
+
+func TestDeactivateMembers(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "deactivate-team",
+		&domain.User{UserID: "dm-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "dm-2", Username: "leaving", IsActive: true},
+		&domain.User{UserID: "dm-3", Username: "staying", IsActive: true},
+	)
+	prRepo := pg.NewPullRequest(testPool)
+	pr := &domain.PullRequest{PullRequestID: "pr-deactivate", PullRequestName: "Deactivate", AuthorID: "dm-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"dm-2"}, CreatedAt: time.Now()}
+	if err := prRepo.Create(ctx, pr); err != nil {
+		t.Fatalf("Failed to create PR: %v", err)
+	}
+	newTeamCase := func(reviews *usecase.PullRequest) *usecase.Team {
+		return usecase.NewTeam(pg.NewTeam(testPool), pg.NewUser(testPool), pg.NewTeamPolicy(testPool), reviews, domain.TeamPolicy{MinReviewers: 1, MaxReviewers: 2})
+	}
+
+	t.Run("Failure Keeps Members Active", func(t *testing.T) {
+		reviews := usecase.NewPullRequest(
+			&failingRedistributionRepo{PullRequest: prRepo}, pg.NewUser(testPool), pg.NewTeam(testPool), pg.NewAssignmentTrace(testPool),
+			pg.NewOwnership(testPool), pg.NewTeamPolicy(testPool), pg.NewReviewerRule(testPool),
+			pg.NewReview(testPool), func() int64 { return 1 }, domain.TeamPolicy{MinReviewers: 1, MaxReviewers: 2}, nil,
+		)
+		if _, err := newTeamCase(reviews).DeactivateMembers(ctx, "deactivate-team", []string{"dm-2"}); err == nil {
+			t.Fatal("Expected deactivation to fail")
+		}
+		user, err := pg.NewUser(testPool).GetByID(ctx, "dm-2")
+		if err != nil {
+			t.Fatalf("Failed to get user: %v", err)
+		}
+		if !user.IsActive {
+			t.Error("Expected dm-2 to stay active when redistribution fails")
+		}
+	})
+
+	t.Run("Deactivate And Redistribute", func(t *testing.T) {
+		report, err := newTeamCase(newPullRequestCase(1)).DeactivateMembers(ctx, "deactivate-team", []string{"dm-2"})
+		if err != nil {
+			t.Fatalf("Failed to deactivate members: %v", err)
+		}
+		if len(report.Deactivated) != 1 || report.Deactivated[0] != "dm-2" {
+			t.Errorf("Expected [dm-2] deactivated, got %v", report.Deactivated)
+		}
+		user, err := pg.NewUser(testPool).GetByID(ctx, "dm-2")
+		if err != nil {
+			t.Fatalf("Failed to get user: %v", err)
+		}
+		if user.IsActive {
+			t.Error("Expected dm-2 to be inactive")
+		}
+		reviewers, err := prRepo.GetReviewers(ctx, "pr-deactivate")
+		if err != nil {
+			t.Fatalf("Failed to get reviewers: %v", err)
+		}
+		if len(reviewers) != 1 || reviewers[0] != "dm-3" {
+			t.Errorf("Expected dm-3 to take over the review, got %v", reviewers)
+		}
+	})
+}
+
+func TestAbsentCandidates(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "absent-team",
+		&domain.User{UserID: "ab-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "ab-2", Username: "away", IsActive: true},
+		&domain.User{UserID: "ab-3", Username: "inactive", IsActive: false},
+		&domain.User{UserID: "ab-4", Username: "present", IsActive: true},
+	)
+	zone := time.FixedZone("UTC+14", 14*60*60)
+	absence := &domain.Absence{
+		UserID:    "ab-2",
+		StartsAt:  time.Now().Add(-30 * time.Minute).In(zone),
+		EndsAt:    time.Now().Add(time.Hour).In(zone),
+		CreatedAt: time.Now(),
+	}
+	if err := pg.NewAbsence(testPool).Create(ctx, absence); err != nil {
+		t.Fatalf("Failed to create absence: %v", err)
+	}
+
+	preview, err := newPullRequestCase(1).PreviewAssignment(ctx, &usecase.CreatePullRequestParams{AuthorID: "ab-1"})
+	if err != nil {
+		t.Fatalf("Failed to preview assignment: %v", err)
+	}
+	reasons := make(map[string]domain.ExclusionReason)
+	for _, candidate := range preview.Candidates {
+		reasons[candidate.UserID] = candidate.Reason
+	}
+	if reasons["ab-2"] != domain.ExclusionAbsent {
+		t.Errorf("Expected ab-2 excluded as ABSENT regardless of the absence time zone, got %q", reasons["ab-2"])
+	}
+	if reasons["ab-3"] != domain.ExclusionInactive {
+		t.Errorf("Expected ab-3 excluded as INACTIVE, got %q", reasons["ab-3"])
+	}
+	if len(preview.ProposedReviewers) != 1 || preview.ProposedReviewers[0] != "ab-4" {
+		t.Errorf("Expected [ab-4] proposed, got %v", preview.ProposedReviewers)
+	}
+}
+
+func TestPreviewMatchesCreate(t *testing.T) {
+	cleanupDB(t)
+	teamRepo := pg.NewTeam(testPool)
+	for _, strategy := range []domain.ReviewerStrategy{domain.StrategyRandom, domain.StrategyRoundRobin} {
+		teamName := "preview-" + string(strategy)
+		prefix := "pv-" + string(strategy)
+		createTeamWithUsers(t, teamName,
+			&domain.User{UserID: prefix + "-1", Username: "author", IsActive: true},
+			&domain.User{UserID: prefix + "-2", Username: "second", IsActive: true},
+			&domain.User{UserID: prefix + "-3", Username: "third", IsActive: true},
+			&domain.User{UserID: prefix + "-4", Username: "fourth", IsActive: true},
+			&domain.User{UserID: prefix + "-5", Username: "fifth", IsActive: true},
+		)
+		if err := teamRepo.SetReviewerStrategy(ctx, teamName, strategy); err != nil {
+			t.Fatalf("Failed to set strategy: %v", err)
+		}
+
+		t.Run(string(strategy), func(t *testing.T) {
+			for seed := int64(1); seed <= 3; seed++ {
+				cases := newPullRequestCase(seed)
+				params := &usecase.CreatePullRequestParams{
+					PullRequestID:   fmt.Sprintf("pr-%s-%d", prefix, seed),
+					PullRequestName: "Preview",
+					AuthorID:        prefix + "-1",
+				}
+				cursor, err := teamRepo.GetRoundRobinCursor(ctx, teamName)
+				if err != nil {
+					t.Fatalf("Failed to get cursor: %v", err)
+				}
+
+				preview, err := cases.PreviewAssignment(ctx, params)
+				if err != nil {
+					t.Fatalf("Failed to preview assignment: %v", err)
+				}
+				_, err = pg.NewPullRequest(testPool).GetByID(ctx, params.PullRequestID)
+				expectDomainError(t, err, domain.ErrNotFound)
+				traces, err := pg.NewAssignmentTrace(testPool).GetByPullRequestID(ctx, params.PullRequestID)
+				if err != nil {
+					t.Fatalf("Failed to get traces: %v", err)
+				}
+				if len(traces) != 0 {
+					t.Errorf("Expected preview to store no trace, got %d", len(traces))
+				}
+				after, err := teamRepo.GetRoundRobinCursor(ctx, teamName)
+				if err != nil {
+					t.Fatalf("Failed to get cursor: %v", err)
+				}
+				if after != cursor {
+					t.Errorf("Expected preview to keep cursor %q, got %q", cursor, after)
+				}
+
+				pr, err := cases.CreatePullRequest(ctx, params)
+				if err != nil {
+					t.Fatalf("Failed to create PR: %v", err)
+				}
+				if fmt.Sprint(pr.AssignedReviewers) != fmt.Sprint(preview.ProposedReviewers) {
+					t.Errorf("Seed %d: expected create to assign preview %v, got %v", seed, preview.ProposedReviewers, pr.AssignedReviewers)
+				}
+			}
+		})
+	}
+}
+
+func TestReopenPullRequest(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "reopen-team",
+		&domain.User{UserID: "rp-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "rp-2", Username: "away", IsActive: true},
+		&domain.User{UserID: "rp-3", Username: "present", IsActive: true},
+	)
+	policy := &domain.TeamPolicy{TeamName: "reopen-team", MinReviewers: 1, MaxReviewers: 1, AllowCrossTeam: true, WorkingHoursMode: domain.WorkingHoursSoft}
+	if err := pg.NewTeamPolicy(testPool).Upsert(ctx, policy); err != nil {
+		t.Fatalf("Failed to upsert policy: %v", err)
+	}
+	prRepo := pg.NewPullRequest(testPool)
+	pr := &domain.PullRequest{PullRequestID: "pr-reopen", PullRequestName: "Reopen", AuthorID: "rp-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"rp-2"}, CreatedAt: time.Now()}
+	if err := prRepo.Create(ctx, pr); err != nil {
+		t.Fatalf("Failed to create PR: %v", err)
+	}
+	cases := newPullRequestCase(1)
+
+	t.Run("Absent Reviewer Is Handed Off", func(t *testing.T) {
+		if _, err := cases.ClosePullRequest(ctx, "pr-reopen"); err != nil {
+			t.Fatalf("Failed to close PR: %v", err)
+		}
+		absence := &domain.Absence{UserID: "rp-2", StartsAt: time.Now().Add(-time.Hour), EndsAt: time.Now().Add(time.Hour), CreatedAt: time.Now()}
+		if err := pg.NewAbsence(testPool).Create(ctx, absence); err != nil {
+			t.Fatalf("Failed to create absence: %v", err)
+		}
+		reopened, handoffs, err := cases.ReopenPullRequest(ctx, "pr-reopen")
+		if err != nil {
+			t.Fatalf("Failed to reopen PR: %v", err)
+		}
+		if reopened.Status != domain.PRStatusOpen {
+			t.Errorf("Expected status OPEN, got %s", reopened.Status)
+		}
+		if len(handoffs) != 1 || handoffs[0].FromUserID != "rp-2" || handoffs[0].ReplacedBy != "rp-3" {
+			t.Errorf("Expected rp-2 handed off to rp-3, got %v", handoffs)
+		}
+		if len(reopened.AssignedReviewers) != 1 || reopened.AssignedReviewers[0] != "rp-3" {
+			t.Errorf("Expected [rp-3] assigned, got %v", reopened.AssignedReviewers)
+		}
+	})
+
+	t.Run("Closed Draft Stays Draft", func(t *testing.T) {
+		_, err := cases.CreatePullRequest(ctx, &usecase.CreatePullRequestParams{
+			PullRequestID:   "pr-reopen-draft",
+			PullRequestName: "Draft",
+			AuthorID:        "rp-1",
+			Draft:           true,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create draft: %v", err)
+		}
+		if _, err := cases.ClosePullRequest(ctx, "pr-reopen-draft"); err != nil {
+			t.Fatalf("Failed to close draft: %v", err)
+		}
+		reopened, handoffs, err := cases.ReopenPullRequest(ctx, "pr-reopen-draft")
+		if err != nil {
+			t.Fatalf("Failed to reopen draft: %v", err)
+		}
+		if reopened.Status != domain.PRStatusDraft {
+			t.Errorf("Expected status DRAFT, got %s", reopened.Status)
+		}
+		if len(handoffs) != 0 || len(reopened.AssignedReviewers) != 0 {
+			t.Errorf("Expected a reopened draft without reviewers, got %v", reopened.AssignedReviewers)
+		}
+	})
+}
+
+func TestSubmittedVerdictKeepsReviewer(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "verdict-team",
+		&domain.User{UserID: "vk-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "vk-2", Username: "reviewer", IsActive: true},
+		&domain.User{UserID: "vk-3", Username: "spare", IsActive: true},
+	)
+	prRepo := pg.NewPullRequest(testPool)
+	pr := &domain.PullRequest{PullRequestID: "pr-verdict", PullRequestName: "Verdict", AuthorID: "vk-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"vk-2"}, CreatedAt: time.Now()}
+	if err := prRepo.Create(ctx, pr); err != nil {
+		t.Fatalf("Failed to create PR: %v", err)
+	}
+	cases := newPullRequestCase(1)
+	if _, _, err := cases.SubmitReview(ctx, "pr-verdict", "vk-2", domain.VerdictApproved, ""); err != nil {
+		t.Fatalf("Failed to submit review: %v", err)
+	}
+
+	t.Run("Rebalancer Skips Reviewed Assignments", func(t *testing.T) {
+		all, err := prRepo.GetOpenAssignments(ctx, []string{"vk-2"}, 0, false)
+		if err != nil {
+			t.Fatalf("Failed to get open assignments: %v", err)
+		}
+		unreviewed, err := prRepo.GetOpenAssignments(ctx, []string{"vk-2"}, 0, true)
+		if err != nil {
+			t.Fatalf("Failed to get open assignments: %v", err)
+		}
+		if len(all) != 1 || len(unreviewed) != 0 {
+			t.Errorf("Expected the reviewed assignment only without the filter, got %d and %d", len(all), len(unreviewed))
+		}
+	})
+
+	t.Run("User Reviews Carry Reviewer States", func(t *testing.T) {
+		reviews, err := cases.GetUserReviews(ctx, "vk-2")
+		if err != nil {
+			t.Fatalf("Failed to get user reviews: %v", err)
+		}
+		if len(reviews) != 1 || len(reviews[0].Reviewers) != 1 {
+			t.Fatalf("Expected one review with one reviewer state, got %+v", reviews)
+		}
+		if state := reviews[0].Reviewers[0]; state.UserID != "vk-2" || state.State != string(domain.VerdictApproved) {
+			t.Errorf("Expected vk-2 APPROVED, got %+v", state)
+		}
+	})
+
+	t.Run("Reassign Rejects Reviewer With Verdict", func(t *testing.T) {
+		_, _, err := cases.ReassignReviewer(ctx, "pr-verdict", "vk-2", "")
+		expectDomainError(t, err, domain.ErrReviewSubmitted)
+		reviewers, err := prRepo.GetReviewers(ctx, "pr-verdict")
+		if err != nil {
+			t.Fatalf("Failed to get reviewers: %v", err)
+		}
+		if len(reviewers) != 1 || reviewers[0] != "vk-2" {
+			t.Errorf("Expected vk-2 to stay assigned, got %v", reviewers)
+		}
+	})
+}
+
+func TestRequiredApprovalsBoundedByMinReviewers(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "approvals-team")
+	teams := usecase.NewTeam(pg.NewTeam(testPool), pg.NewUser(testPool), pg.NewTeamPolicy(testPool), newPullRequestCase(1), domain.TeamPolicy{MinReviewers: 1, MaxReviewers: 3, WorkingHoursMode: domain.WorkingHoursSoft})
+	two, three := 2, 3
+
+	_, err := teams.SetPolicy(ctx, "approvals-team", &domain.TeamPolicyUpdate{RequiredApprovals: &two})
+	expectDomainError(t, err, domain.ErrInvalidPolicy)
+
+	policy, err := teams.SetPolicy(ctx, "approvals-team", &domain.TeamPolicyUpdate{MinReviewers: &three, RequiredApprovals: &two})
+	if err != nil {
+		t.Fatalf("Failed to set policy: %v", err)
+	}
+	if policy.RequiredApprovals != 2 {
+		t.Errorf("Expected 2 required approvals, got %d", policy.RequiredApprovals)
+	}
+}
+
+func TestReviewQueueDrainsOnRemoval(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "queue-team",
+		&domain.User{UserID: "qu-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "qu-2", Username: "reviewer", IsActive: true},
+	)
+	policy := &domain.TeamPolicy{TeamName: "queue-team", MinReviewers: 0, MaxReviewers: 1, AllowCrossTeam: true, WorkingHoursMode: domain.WorkingHoursSoft, MaxOpenReviews: 1}
+	if err := pg.NewTeamPolicy(testPool).Upsert(ctx, policy); err != nil {
+		t.Fatalf("Failed to upsert policy: %v", err)
+	}
+	cases := newPullRequestCase(1)
+	first, err := cases.CreatePullRequest(ctx, &usecase.CreatePullRequestParams{PullRequestID: "pr-queue-1", PullRequestName: "First", AuthorID: "qu-1"})
+	if err != nil {
+		t.Fatalf("Failed to create first PR: %v", err)
+	}
+	if len(first.AssignedReviewers) != 1 {
+		t.Fatalf("Expected qu-2 on the first PR, got %v", first.AssignedReviewers)
+	}
+	second, err := cases.CreatePullRequest(ctx, &usecase.CreatePullRequestParams{PullRequestID: "pr-queue-2", PullRequestName: "Second", AuthorID: "qu-1"})
+	if err != nil {
+		t.Fatalf("Failed to create second PR: %v", err)
+	}
+	if len(second.AssignedReviewers) != 0 || !second.UnderReviewed {
+		t.Fatalf("Expected the second PR queued without reviewers under min_reviewers 0, got %v", second.AssignedReviewers)
+	}
+
+	if _, err := cases.RemoveReviewer(ctx, "pr-queue-1", "qu-2"); err != nil {
+		t.Fatalf("Failed to remove reviewer: %v", err)
+	}
+	for prID, want := range map[string]int{"pr-queue-1": 0, "pr-queue-2": 1} {
+		reviewers, err := pg.NewPullRequest(testPool).GetReviewers(ctx, prID)
+		if err != nil {
+			t.Fatalf("Failed to get reviewers: %v", err)
+		}
+		if len(reviewers) != want {
+			t.Errorf("Expected %d reviewers on %s after the removal freed qu-2, got %v", want, prID, reviewers)
+		}
+	}
+}
+
+func TestRebalanceFollowsAuthorRules(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "rebalance-team",
+		&domain.User{UserID: "rb-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "rb-2", Username: "donor", IsActive: true},
+		&domain.User{UserID: "rb-3", Username: "receiver", IsActive: true},
+		&domain.User{UserID: "rb-4", Username: "other author", IsActive: true},
+	)
+	prRepo := pg.NewPullRequest(testPool)
+	reassignmentRepo := pg.NewReassignment(testPool)
+	if err := pg.NewReviewerRule(testPool).Create(ctx, &domain.ReviewerRule{Type: domain.RuleNoRepeat, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+
+	// rb-3 reviewed rb-1's latest PR, so NO_REPEAT keeps rb-3 off the
+	// other rb-1 PRs and only rb-4's PR may move.
+	base := time.Now().Add(-time.Hour)
+	prs := []*domain.PullRequest{
+		{PullRequestID: "pr-rb-other", PullRequestName: "Other", AuthorID: "rb-4", AssignedReviewers: []string{"rb-2"}},
+		{PullRequestID: "pr-rb-1", PullRequestName: "First", AuthorID: "rb-1", AssignedReviewers: []string{"rb-2"}},
+		{PullRequestID: "pr-rb-2", PullRequestName: "Second", AuthorID: "rb-1", AssignedReviewers: []string{"rb-2"}},
+		{PullRequestID: "pr-rb-last", PullRequestName: "Last", AuthorID: "rb-1", AssignedReviewers: []string{"rb-3"}},
+	}
+	for i, pr := range prs {
+		pr.Status = domain.PRStatusOpen
+		pr.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create PR %s: %v", pr.PullRequestID, err)
+		}
+	}
+	if err := prRepo.SetMerged(ctx, "pr-rb-last"); err != nil {
+		t.Fatalf("Failed to merge PR: %v", err)
+	}
+
+	policy := domain.TeamPolicy{MinReviewers: 1, MaxReviewers: 2, AllowCrossTeam: true, WorkingHoursMode: domain.WorkingHoursSoft}
+	rebalancer := usecase.NewRebalancer(prRepo, pg.NewUser(testPool), pg.NewTeam(testPool), pg.NewTeamPolicy(testPool), reassignmentRepo, newPullRequestCase(1), policy, usecase.RebalanceSettings{})
+	report, err := rebalancer.Rebalance(ctx, "rebalance-team", false)
+	if err != nil {
+		t.Fatalf("Failed to rebalance: %v", err)
+	}
+	if len(report.Moves) != 1 || report.Moves[0].PullRequestID != "pr-rb-other" || report.Moves[0].ToUserID != "rb-3" {
+		t.Fatalf("Expected only pr-rb-other to move to rb-3, got %+v", report.Moves)
+	}
+
+	reviewers, err := prRepo.GetReviewers(ctx, "pr-rb-other")
+	if err != nil {
+		t.Fatalf("Failed to get reviewers: %v", err)
+	}
+	if len(reviewers) != 1 || reviewers[0] != "rb-3" {
+		t.Errorf("Expected rb-3 on pr-rb-other, got %v", reviewers)
+	}
+	recorded, err := reassignmentRepo.ListRecent(ctx, 10)
+	if err != nil {
+		t.Fatalf("Failed to list reassignments: %v", err)
+	}
+	if len(recorded) != 1 || recorded[0].ID != report.Moves[0].ID {
+		t.Errorf("Expected the move to be recorded, got %+v", recorded)
+	}
+}
+
+func TestActivationTopsUpFallbackTeams(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "topup-helpers",
+		&domain.User{UserID: "tu-2", Username: "helper", IsActive: false},
+	)
+	createTeamWithUsers(t, "topup-authors",
+		&domain.User{UserID: "tu-1", Username: "author", IsActive: true},
+	)
+	if err := pg.NewTeam(testPool).SetFallbackTeams(ctx, "topup-authors", []string{"topup-helpers"}); err != nil {
+		t.Fatalf("Failed to set fallback teams: %v", err)
+	}
+	cases := newPullRequestCase(1)
+	pr, err := cases.CreatePullRequest(ctx, &usecase.CreatePullRequestParams{PullRequestID: "pr-topup", PullRequestName: "Top up", AuthorID: "tu-1"})
+	if err != nil {
+		t.Fatalf("Failed to create PR: %v", err)
+	}
+	if len(pr.AssignedReviewers) != 0 {
+		t.Fatalf("Expected no reviewers before activation, got %v", pr.AssignedReviewers)
+	}
+
+	users := usecase.NewUser(pg.NewUser(testPool), cases)
+	if _, _, err := users.SetIsActive(ctx, "tu-2", true, false); err != nil {
+		t.Fatalf("Failed to activate user: %v", err)
+	}
+	reviewers, err := pg.NewPullRequest(testPool).GetReviewers(ctx, "pr-topup")
+	if err != nil {
+		t.Fatalf("Failed to get reviewers: %v", err)
+	}
+	if len(reviewers) != 1 || reviewers[0] != "tu-2" {
+		t.Errorf("Expected tu-2 from the fallback team on pr-topup, got %v", reviewers)
+	}
+}
+
+func TestDeactivateUserWithHandoffs(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "deactivate-user-team",
+		&domain.User{UserID: "du-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "du-2", Username: "leaving", IsActive: true},
+		&domain.User{UserID: "du-3", Username: "staying", IsActive: true},
+	)
+	prRepo := pg.NewPullRequest(testPool)
+	userRepo := pg.NewUser(testPool)
+	pr := &domain.PullRequest{PullRequestID: "pr-deactivate-user", PullRequestName: "Deactivate user", AuthorID: "du-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"du-2"}, CreatedAt: time.Now()}
+	if err := prRepo.Create(ctx, pr); err != nil {
+		t.Fatalf("Failed to create PR: %v", err)
+	}
+
+	t.Run("Failed Handoff Keeps User Active", func(t *testing.T) {
+		err := prRepo.ApplyRedistribution(ctx, &domain.ReviewRedistribution{
+			Deactivate: []string{"du-2"},
+			Changes:    []*domain.ReviewerChange{{PullRequestID: "pr-deactivate-user", Removed: []string{"du-3"}}},
+		})
+		expectDomainError(t, err, domain.ErrNotAssigned)
+		user, err := userRepo.GetByID(ctx, "du-2")
+		if err != nil {
+			t.Fatalf("Failed to get user: %v", err)
+		}
+		if !user.IsActive {
+			t.Error("Expected du-2 to stay active when the handoff fails")
+		}
+	})
+
+	t.Run("Deactivation Hands Off Reviews", func(t *testing.T) {
+		user, handoffs, err := usecase.NewUser(userRepo, newPullRequestCase(1)).SetIsActive(ctx, "du-2", false, true)
+		if err != nil {
+			t.Fatalf("Failed to deactivate user: %v", err)
+		}
+		if user.IsActive {
+			t.Error("Expected du-2 to be inactive")
+		}
+		if len(handoffs) != 1 || handoffs[0].ReplacedBy != "du-3" {
+			t.Fatalf("Expected du-3 to take over the review, got %+v", handoffs)
+		}
+		reviewers, err := prRepo.GetReviewers(ctx, "pr-deactivate-user")
+		if err != nil {
+			t.Fatalf("Failed to get reviewers: %v", err)
+		}
+		if len(reviewers) != 1 || reviewers[0] != "du-3" {
+			t.Errorf("Expected du-3 on the PR, got %v", reviewers)
+		}
+	})
+}
+
+func TestRedistributeFromAuthorTeam(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "redistribute-helpers",
+		&domain.User{UserID: "rd-2", Username: "leaving", IsActive: true},
+		&domain.User{UserID: "rd-4", Username: "helper", IsActive: true},
+	)
+	createTeamWithUsers(t, "redistribute-authors",
+		&domain.User{UserID: "rd-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "rd-3", Username: "repeat", IsActive: true},
+		&domain.User{UserID: "rd-5", Username: "fresh", IsActive: true},
+	)
+	if err := pg.NewTeam(testPool).SetFallbackTeams(ctx, "redistribute-authors", []string{"redistribute-helpers"}); err != nil {
+		t.Fatalf("Failed to set fallback teams: %v", err)
+	}
+	if err := pg.NewReviewerRule(testPool).Create(ctx, &domain.ReviewerRule{Type: domain.RuleNoRepeat, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	prRepo := pg.NewPullRequest(testPool)
+	prs := []*domain.PullRequest{
+		{PullRequestID: "pr-redistribute", PullRequestName: "Redistribute", AuthorID: "rd-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"rd-2"}, CreatedAt: time.Now().Add(-time.Hour)},
+		{PullRequestID: "pr-redistribute-previous", PullRequestName: "Previous", AuthorID: "rd-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"rd-3"}, CreatedAt: time.Now()},
+	}
+	for _, pr := range prs {
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create PR %s: %v", pr.PullRequestID, err)
+		}
+	}
+	if err := prRepo.SetMerged(ctx, "pr-redistribute-previous"); err != nil {
+		t.Fatalf("Failed to merge PR: %v", err)
+	}
+
+	report, err := newPullRequestCase(1).RedistributeReviews(ctx, []string{"rd-2"})
+	if err != nil {
+		t.Fatalf("Failed to redistribute reviews: %v", err)
+	}
+	if len(report) != 1 || len(report[0].Handoffs) != 1 || report[0].Handoffs[0].ReplacedBy != "rd-5" {
+		t.Fatalf("Expected rd-5 from the author's team to take over, got %+v", report)
+	}
+	traces, err := pg.NewAssignmentTrace(testPool).GetByPullRequestID(ctx, "pr-redistribute")
+	if err != nil {
+		t.Fatalf("Failed to get traces: %v", err)
+	}
+	if len(traces) != 1 || traces[0].Action != domain.AssignmentActionReassign || len(traces[0].Selected) != 1 || traces[0].Selected[0] != "rd-5" {
+		t.Errorf("Expected a REASSIGN trace selecting rd-5, got %+v", traces)
+	}
+}
+
+func TestDeclineReview(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "decline-case-team",
+		&domain.User{UserID: "dr-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "dr-2", Username: "decliner", IsActive: true},
+		&domain.User{UserID: "dr-3", Username: "stand-in", IsActive: true},
+	)
+	prRepo := pg.NewPullRequest(testPool)
+	declineRepo := pg.NewReviewDecline(testPool)
+	pr := &domain.PullRequest{PullRequestID: "pr-decline-case", PullRequestName: "Decline", AuthorID: "dr-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"dr-2"}, CreatedAt: time.Now()}
+	if err := prRepo.Create(ctx, pr); err != nil {
+		t.Fatalf("Failed to create PR: %v", err)
+	}
+	cases := newPullRequestCase(1)
+
+	t.Run("Decline Is Recorded With The Replacement", func(t *testing.T) {
+		updated, decline, err := cases.DeclineReview(ctx, "pr-decline-case", "dr-2", domain.DeclineTooBusy, "")
+		if err != nil {
+			t.Fatalf("Failed to decline review: %v", err)
+		}
+		if decline.ID == 0 || decline.ReplacedBy != "dr-3" {
+			t.Errorf("Expected a stored decline replaced by dr-3, got %+v", decline)
+		}
+		if len(updated.AssignedReviewers) != 1 || updated.AssignedReviewers[0] != "dr-3" {
+			t.Errorf("Expected dr-3 on the PR, got %v", updated.AssignedReviewers)
+		}
+	})
+
+	t.Run("Failed Decline Is Not Recorded", func(t *testing.T) {
+		_, _, err := cases.DeclineReview(ctx, "pr-decline-case", "dr-2", domain.DeclineTooBusy, "")
+		expectDomainError(t, err, domain.ErrNotAssigned)
+		counts, err := declineRepo.GetCountsByUsers(ctx, []string{"dr-2"})
+		if err != nil {
+			t.Fatalf("Failed to get decline counts: %v", err)
+		}
+		if counts["dr-2"][domain.DeclineTooBusy] != 1 {
+			t.Errorf("Expected a single TOO_BUSY decline for dr-2, got %d", counts["dr-2"][domain.DeclineTooBusy])
+		}
+	})
+}
+
+func TestCommentedVerdictKeepsState(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "commented-team",
+		&domain.User{UserID: "cm-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "cm-2", Username: "reviewer", IsActive: true},
+	)
+	prRepo := pg.NewPullRequest(testPool)
+	for _, prID := range []string{"pr-commented-approved", "pr-commented-changes"} {
+		pr := &domain.PullRequest{PullRequestID: prID, PullRequestName: prID, AuthorID: "cm-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"cm-2"}, CreatedAt: time.Now()}
+		if err := prRepo.Create(ctx, pr); err != nil {
+			t.Fatalf("Failed to create PR %s: %v", prID, err)
+		}
+	}
+	cases := newPullRequestCase(1)
+	submit := func(t *testing.T, prID string, verdicts ...domain.ReviewVerdict) *domain.PullRequest {
+		t.Helper()
+		var pr *domain.PullRequest
+		for _, verdict := range verdicts {
+			var err error
+			pr, _, err = cases.SubmitReview(ctx, prID, "cm-2", verdict, "")
+			if err != nil {
+				t.Fatalf("Failed to submit %s: %v", verdict, err)
+			}
+		}
+		return pr
+	}
+
+	t.Run("Comment After Changes Requested Keeps Blocking", func(t *testing.T) {
+		pr := submit(t, "pr-commented-changes", domain.VerdictChangesRequested, domain.VerdictCommented)
+		if state := pr.Reviewers[0].State; state != string(domain.VerdictChangesRequested) {
+			t.Errorf("Expected CHANGES_REQUESTED, got %s", state)
+		}
+		_, _, err := cases.MergePullRequest(ctx, &usecase.MergePullRequestParams{PullRequestID: "pr-commented-changes"})
+		expectDomainError(t, err, domain.ErrNotApproved)
+	})
+
+	t.Run("Comment After Approval Keeps Approval", func(t *testing.T) {
+		pr := submit(t, "pr-commented-approved", domain.VerdictCommented, domain.VerdictApproved, domain.VerdictCommented)
+		if state := pr.Reviewers[0].State; state != string(domain.VerdictApproved) {
+			t.Errorf("Expected APPROVED, got %s", state)
+		}
+		merged, _, err := cases.MergePullRequest(ctx, &usecase.MergePullRequestParams{PullRequestID: "pr-commented-approved"})
+		if err != nil {
+			t.Fatalf("Failed to merge PR: %v", err)
+		}
+		if merged.Status != domain.PRStatusMerged {
+			t.Errorf("Expected status MERGED, got %s", merged.Status)
+		}
+	})
+}
+
+func TestForceMergeRequiresAdminToken(t *testing.T) {
+	cleanupDB(t)
+	createTeamWithUsers(t, "force-team",
+		&domain.User{UserID: "fm-1", Username: "author", IsActive: true},
+		&domain.User{UserID: "fm-2", Username: "reviewer", IsActive: true},
+	)
+	pr := &domain.PullRequest{PullRequestID: "pr-force", PullRequestName: "Force", AuthorID: "fm-1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"fm-2"}, CreatedAt: time.Now()}
+	if err := pg.NewPullRequest(testPool).Create(ctx, pr); err != nil {
+		t.Fatalf("Failed to create PR: %v", err)
+	}
+	cases := newAdminPullRequestCase(1, map[string]string{"secret": "fm-admin"})
+	if _, _, err := cases.SubmitReview(ctx, "pr-force", "fm-2", domain.VerdictChangesRequested, ""); err != nil {
+		t.Fatalf("Failed to submit review: %v", err)
+	}
+
+	for _, token := range []string{"", "guess"} {
+		_, _, err := cases.MergePullRequest(ctx, &usecase.MergePullRequestParams{PullRequestID: "pr-force", Force: true, AdminToken: token, OverrideReason: "hotfix"})
+		expectDomainError(t, err, domain.ErrForbidden)
+	}
+
+	merged, override, err := cases.MergePullRequest(ctx, &usecase.MergePullRequestParams{PullRequestID: "pr-force", Force: true, AdminToken: "secret", OverrideReason: "hotfix"})
+	if err != nil {
+		t.Fatalf("Failed to force merge: %v", err)
+	}
+	if merged.Status != domain.PRStatusMerged {
+		t.Errorf("Expected status MERGED, got %s", merged.Status)
+	}
+	if override == nil || override.UserID != "fm-admin" {
+		t.Errorf("Expected override by fm-admin, got %+v", override)
+	}
+}